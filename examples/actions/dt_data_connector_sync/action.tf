@@ -0,0 +1,7 @@
+# Copyright (c) HashiCorp, Inc.
+
+action "dt_data_connector_sync" "test_delivery" {
+  config {
+    data_connector = dt_data_connector.webhook.name
+  }
+}
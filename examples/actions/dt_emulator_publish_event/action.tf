@@ -0,0 +1,9 @@
+# Copyright (c) HashiCorp, Inc.
+
+action "dt_emulator_publish_event" "too_warm" {
+  config {
+    emulator   = dt_emulator.fridge.name
+    event_type = "temperature"
+    data       = jsonencode({ value = 12.5 })
+  }
+}
@@ -0,0 +1,8 @@
+# Copyright (c) HashiCorp, Inc.
+
+action "dt_notification_rule_set_enabled" "maintenance" {
+  config {
+    notification_rule = dt_notification_rule.freezer.name
+    enabled           = false
+  }
+}
@@ -0,0 +1,14 @@
+# Copyright (c) HashiCorp, Inc.
+
+ephemeral "dt_access_token" "token" {
+  provider = disruptive-technologies
+}
+
+# The token can be used in other ephemeral contexts, such as the configuration
+# of a provider calling DT endpoints that this provider doesn't cover.
+provider "restapi" {
+  uri = "https://api.disruptive-technologies.com/v2"
+  headers = {
+    Authorization = "${ephemeral.dt_access_token.token.token_type} ${ephemeral.dt_access_token.token.access_token}"
+  }
+}
@@ -0,0 +1,12 @@
+# Copyright (c) HashiCorp, Inc.
+
+list "dt_device" "kitchen" {
+  provider = disruptive-technologies
+
+  config {
+    project_id = "c8n5cmpsd1f4ma0p5jt0"
+    labels = {
+      room = "kitchen"
+    }
+  }
+}
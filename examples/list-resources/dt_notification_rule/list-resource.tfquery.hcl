@@ -0,0 +1,9 @@
+# Copyright (c) HashiCorp, Inc.
+
+list "dt_notification_rule" "legacy" {
+  provider = disruptive-technologies
+
+  config {
+    project_id = "c8n5cmpsd1f4ma0p5jt0"
+  }
+}
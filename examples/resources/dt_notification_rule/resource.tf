@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_project" "my_project" {
-  display_name = "Notification Rule Acceptance Test Project"
+  display_name = "Terraform created notification rule project"
   organization = "organizations/cvinmt9aq9sc738g6eog"
   location = {
     time_location = "Europe/Oslo"
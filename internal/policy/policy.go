@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+
+// Package policy checks display names and labels against the naming and
+// labelling policy of the provider.
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Policy holds the naming and labelling conventions that resources are checked
+// against before they are planned. A nil Policy allows everything.
+type Policy struct {
+	// DisplayNamePattern must match the display name of every resource, if set.
+	DisplayNamePattern *regexp.Regexp
+	// AllowedLabelKeys are the only label keys that may be used, if set.
+	AllowedLabelKeys []string
+	// LabelValuePatterns must match the value of the label with the same key.
+	LabelValuePatterns map[string]*regexp.Regexp
+	// Severity is either SeverityError or SeverityWarning.
+	Severity string
+}
+
+// IsWarning reports whether policy violations are reported as warnings rather than errors.
+func (p *Policy) IsWarning() bool {
+	return p != nil && p.Severity == SeverityWarning
+}
+
+// CheckDisplayName returns an error if the display name violates the policy.
+func (p *Policy) CheckDisplayName(displayName string) error {
+	if p == nil || p.DisplayNamePattern == nil {
+		return nil
+	}
+	if !p.DisplayNamePattern.MatchString(displayName) {
+		return fmt.Errorf("display name %q does not match the pattern %q", displayName, p.DisplayNamePattern)
+	}
+	return nil
+}
+
+// CheckLabelKey returns an error if the label key is not allowed by the policy.
+func (p *Policy) CheckLabelKey(key string) error {
+	if p == nil || p.AllowedLabelKeys == nil {
+		return nil
+	}
+	if !slices.Contains(p.AllowedLabelKeys, key) {
+		return fmt.Errorf("label key %q is not allowed, allowed keys are: %s", key, strings.Join(p.AllowedLabelKeys, ", "))
+	}
+	return nil
+}
+
+// CheckLabel returns an error if the label key or value violates the policy.
+func (p *Policy) CheckLabel(key, value string) error {
+	if err := p.CheckLabelKey(key); err != nil {
+		return err
+	}
+	if p == nil {
+		return nil
+	}
+	if pattern, ok := p.LabelValuePatterns[key]; ok && !pattern.MatchString(value) {
+		return fmt.Errorf("value %q of label %q does not match the pattern %q", value, key, pattern)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+
+package policy
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{
+		DisplayNamePattern: regexp.MustCompile(`^team-a-`),
+		AllowedLabelKeys:   []string{"cost-centre", "managed-by"},
+		LabelValuePatterns: map[string]*regexp.Regexp{"cost-centre": regexp.MustCompile(`^\d{4}$`)},
+		Severity:           SeverityError,
+	}
+
+	tests := map[string]struct {
+		check   func(p *Policy) error
+		wantErr bool
+	}{
+		"matching display name": {
+			check: func(p *Policy) error { return p.CheckDisplayName("team-a-sensors") },
+		},
+		"display name without prefix": {
+			check:   func(p *Policy) error { return p.CheckDisplayName("sensors") },
+			wantErr: true,
+		},
+		"allowed label": {
+			check: func(p *Policy) error { return p.CheckLabel("cost-centre", "1234") },
+		},
+		"label key not allowed": {
+			check:   func(p *Policy) error { return p.CheckLabelKey("owner") },
+			wantErr: true,
+		},
+		"label value not matching": {
+			check:   func(p *Policy) error { return p.CheckLabel("cost-centre", "marketing") },
+			wantErr: true,
+		},
+		"label without value pattern": {
+			check: func(p *Policy) error { return p.CheckLabel("managed-by", "anything") },
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.check(policy); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %t, got: %v", tt.wantErr, err)
+			}
+			// a nil policy allows everything
+			if err := tt.check(nil); err != nil {
+				t.Errorf("unexpected error from nil policy: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+
+package profile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfile is the profile used when no profile is selected.
+const DefaultProfile = "default"
+
+// ErrNotFound is returned by Load when the configuration file does not contain the requested profile.
+var ErrNotFound = errors.New("profile not found")
+
+// Profile is a named set of connection settings read from a DT configuration file.
+type Profile struct {
+	// The DT environment to take the default endpoints from, e.g. production.
+	Environment string
+	// The URL of the API server.
+	URL string
+	// The URL of the emulator server.
+	EmulatorURL string
+	// The token endpoint for the OIDC provider.
+	TokenEndpoint string
+	// The issuer of the OIDC provider, used to discover the token endpoint.
+	Issuer string
+	// The key ID from the service account.
+	KeyID string
+	// The key secret from the service account.
+	KeySecret string
+	// The email address used to authenticate with the OIDC provider.
+	Email string
+	// Path to a PEM encoded private key used to sign the JWT assertion.
+	PrivateKeyFile string
+}
+
+// DefaultPath returns the default location of the DT configuration file: ~/.config/dt/config.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("profile: failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "dt", "config"), nil
+}
+
+// Load reads the configuration file at path and returns the profile with the given name.
+func Load(path, name string) (Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("profile: failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	profiles, err := Parse(file)
+	if err != nil {
+		return Profile{}, fmt.Errorf("profile: failed to parse %s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile: %w: %q in %s", ErrNotFound, name, path)
+	}
+	return profile, nil
+}
+
+// Parse reads profiles from an INI style configuration file. Each section is a
+// profile, and values may optionally be quoted so that simple TOML files are
+// also accepted:
+//
+//	[staging]
+//	url            = "https://api.example.com"
+//	token_endpoint = "https://identity.example.com/oauth2/token"
+func Parse(r io.Reader) (map[string]Profile, error) {
+	profiles := make(map[string]Profile)
+	var current string
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		// skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid section header: %s", lineNumber, line)
+			}
+			current = strings.TrimSpace(strings.Trim(line, "[]"))
+			// allow the AWS style "[profile name]" section header
+			current = strings.TrimSpace(strings.TrimPrefix(current, "profile "))
+			if current == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+			if _, ok := profiles[current]; !ok {
+				profiles[current] = Profile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value, got: %s", lineNumber, line)
+		}
+		if current == "" {
+			return nil, fmt.Errorf("line %d: key outside of a profile section", lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		value = unquote(strings.TrimSpace(value))
+
+		profile := profiles[current]
+		switch key {
+		case "environment":
+			profile.Environment = value
+		case "url":
+			profile.URL = value
+		case "emulator_url":
+			profile.EmulatorURL = value
+		case "token_endpoint":
+			profile.TokenEndpoint = value
+		case "issuer":
+			profile.Issuer = value
+		case "key_id":
+			profile.KeyID = value
+		case "key_secret":
+			profile.KeySecret = value
+		case "email":
+			profile.Email = value
+		case "private_key_file":
+			profile.PrivateKeyFile = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q", lineNumber, key)
+		}
+		profiles[current] = profile
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// IsNotExist reports whether err was returned because the configuration file does not exist.
+func IsNotExist(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
+
+// IsNotFound reports whether err was returned because the requested profile does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfig = `
+# Shared DT configuration
+[default]
+url    = https://api.disruptive-technologies.com
+issuer = https://identity.disruptive-technologies.com
+
+[staging]
+url            = "https://api.staging.example.com"
+emulator_url   = "https://emulator.staging.example.com"
+token_endpoint = 'https://identity.staging.example.com/oauth2/token'
+key_id         = staging-key
+key_secret     = staging-secret
+email          = staging@example.com
+
+; AWS style section header
+[profile production]
+environment      = production
+url              = https://api.production.example.com
+private_key_file = /etc/dt/production.pem
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	profiles, err := Parse(strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]Profile{
+		"default": {
+			URL:    "https://api.disruptive-technologies.com",
+			Issuer: "https://identity.disruptive-technologies.com",
+		},
+		"staging": {
+			URL:           "https://api.staging.example.com",
+			EmulatorURL:   "https://emulator.staging.example.com",
+			TokenEndpoint: "https://identity.staging.example.com/oauth2/token",
+			KeyID:         "staging-key",
+			KeySecret:     "staging-secret",
+			Email:         "staging@example.com",
+		},
+		"production": {
+			Environment:    "production",
+			URL:            "https://api.production.example.com",
+			PrivateKeyFile: "/etc/dt/production.pem",
+		},
+	}
+	if len(profiles) != len(want) {
+		t.Fatalf("expected %d profiles, got %d: %v", len(want), len(profiles), profiles)
+	}
+	for name, expected := range want {
+		if got := profiles[name]; got != expected {
+			t.Errorf("profile %q: expected %+v, got %+v", name, expected, got)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"key outside section": "url = https://api.example.com",
+		"unknown key":         "[default]\nregion = eu",
+		"missing equals":      "[default]\nurl",
+		"unterminated header": "[default\nurl = https://api.example.com",
+		"empty profile name":  "[]",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := Parse(strings.NewReader(input)); err == nil {
+				t.Errorf("expected an error for input %q", input)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	prof, err := Load(path, "production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prof.URL != "https://api.production.example.com" {
+		t.Errorf("unexpected url: %s", prof.URL)
+	}
+
+	_, err = Load(path, "missing")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+
+	_, err = Load(filepath.Join(t.TempDir(), "does-not-exist"), DefaultProfile)
+	if !IsNotExist(err) {
+		t.Errorf("expected not exist error, got: %v", err)
+	}
+}
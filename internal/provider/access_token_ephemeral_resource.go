@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &accessTokenEphemeralResource{}
+)
+
+// NewAccessTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenEphemeralResource{}
+}
+
+// accessTokenEphemeralResource is the ephemeral resource implementation.
+type accessTokenEphemeralResource struct {
+	client *providerClient
+}
+
+// accessTokenEphemeralResourceModel is the data model for the ephemeral resource.
+type accessTokenEphemeralResourceModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	ExpiresIn   types.Int64  `tfsdk:"expires_in"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *accessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *accessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "An access token for the DT API, obtained with the provider's service account. " +
+			"The token is never stored in the plan or state, and can be used to call DT endpoints that the provider doesn't cover.",
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access token, sent in the `Authorization` header as `<token_type> <access_token>`.",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The type of the token, typically `Bearer`.",
+			},
+			"expires_in": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of seconds until the token expires.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the token expires, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+// Open fetches an access token.
+func (e *accessTokenEphemeralResource) Open(ctx context.Context, _ ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	token, err := e.client.AccessToken(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get access token", err.Error())
+		return
+	}
+
+	expiry := time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	result := accessTokenEphemeralResourceModel{
+		AccessToken: types.StringValue(token.AccessToken),
+		TokenType:   types.StringValue(token.TokenType),
+		ExpiresIn:   types.Int64Value(int64(token.ExpiresIn)),
+		ExpiresAt:   types.StringValue(expiry.UTC().Format(time.RFC3339)),
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
+
+func (e *accessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"invalid provider data",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = client
+}
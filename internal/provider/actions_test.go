@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// invokeAction invokes the action against a test server with the config values
+// and returns the request bodies the server received by path.
+func invokeAction(t *testing.T, a action.Action, handler http.HandlerFunc, values map[string]tftypes.Value) (map[string]string, action.InvokeResponse) {
+	t.Helper()
+
+	bodies := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies[r.Method+" "+r.URL.Path] = string(body)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	client, err := newProviderClient(providerSettings{
+		URL:         server.URL,
+		EmulatorURL: server.URL,
+		KeyID:       "key",
+		KeySecret:   "secret",
+		Email:       "service-account@example.com",
+	}, server.URL+"/oauth2/token", "test")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	a.(action.ActionWithConfigure).Configure(ctx, action.ConfigureRequest{ProviderData: client}, &action.ConfigureResponse{})
+
+	var schemaResp action.SchemaResponse
+	a.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+	req := action.InvokeRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), values),
+		},
+	}
+	resp := action.InvokeResponse{SendProgress: func(action.InvokeProgressEvent) {}}
+	a.Invoke(ctx, req, &resp)
+	return bodies, resp
+}
+
+func TestEmulatorPublishEventAction(t *testing.T) {
+	t.Parallel()
+
+	bodies, resp := invokeAction(t, NewEmulatorPublishEventAction(), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}, map[string]tftypes.Value{
+		"emulator":   tftypes.NewValue(tftypes.String, "projects/p1/devices/d1"),
+		"event_type": tftypes.NewValue(tftypes.String, "temperature"),
+		"data":       tftypes.NewValue(tftypes.String, `{"value":22.5}`),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if got := bodies["POST /v2/projects/p1/devices/d1:publish"]; got != `{"temperature":{"value":22.5}}` {
+		t.Errorf("unexpected publish request: %q", got)
+	}
+}
+
+func TestNotificationRuleSetEnabledAction(t *testing.T) {
+	t.Parallel()
+
+	bodies, resp := invokeAction(t, NewNotificationRuleSetEnabledAction(), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"rules":[{"name":"projects/p1/rules/r1","enabled":true,"displayName":"Freezer"}]}`)
+			return
+		}
+		body, _ := json.Marshal(map[string]any{"name": "projects/p1/rules/r1", "enabled": false, "displayName": "Freezer"})
+		w.Write(body)
+	}, map[string]tftypes.Value{
+		"notification_rule": tftypes.NewValue(tftypes.String, "projects/p1/rules/r1"),
+		"enabled":           tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var rule dtapi.NotificationRule
+	if err := json.Unmarshal([]byte(bodies["PUT /v2alpha/projects/p1/rules/r1"]), &rule); err != nil {
+		t.Fatalf("unexpected update request: %v", err)
+	}
+	if rule.Enabled || rule.DisplayName != "Freezer" {
+		t.Errorf("expected only enabled to change, got: %+v", rule)
+	}
+}
+
+func TestEventData(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		data    types.String
+		want    string
+		wantErr bool
+	}{
+		"null":      {data: types.StringNull(), want: `{}`},
+		"object":    {data: types.StringValue(`{"value":22.5}`), want: `{"value":22.5}`},
+		"not json":  {data: types.StringValue(`value=22.5`), wantErr: true},
+		"array":     {data: types.StringValue(`[22.5]`), wantErr: true},
+		"json null": {data: types.StringValue(`null`), wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := eventData(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
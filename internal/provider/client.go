@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"github.com/disruptive-technologies/terraform-provider-dt/internal/policy"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+)
+
+// providerClient is the DT API client together with the provider settings
+// resources apply on top of it. It is the provider data passed to every
+// resource, data source, action and ephemeral resource.
+type providerClient struct {
+	*dtapi.Client
+
+	// DefaultOrganization is the organization ID used by resources that don't set one.
+	DefaultOrganization string
+	// DefaultProject is the project ID used by resources that don't set one.
+	DefaultProject string
+	// DefaultLabels are merged into the labels of labelled resources.
+	DefaultLabels map[string]string
+	// Policy is the naming and labelling policy resources are checked against.
+	Policy *policy.Policy
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &convertTemperatureFunction{}
+
+// NewConvertTemperatureFunction is a helper function to simplify the provider implementation.
+func NewConvertTemperatureFunction() function.Function {
+	return &convertTemperatureFunction{}
+}
+
+// convertTemperatureFunction is the function implementation.
+type convertTemperatureFunction struct{}
+
+func (f *convertTemperatureFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "convert_temperature"
+}
+
+func (f *convertTemperatureFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	units := fmt.Sprintf("`%s`", strings.Join(dtapi.TemperatureUnits(), "`, `"))
+	resp.Definition = function.Definition{
+		Summary: "Convert a temperature between Celsius, Fahrenheit and Kelvin",
+		MarkdownDescription: fmt.Sprintf("Converts a temperature between units, rounded to two decimals so that converted "+
+			"thresholds don't show up as float differences in plans. Units are one of %s, or the unit name, in any case.", units),
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:        "value",
+				Description: "The temperature to convert.",
+			},
+			function.StringParameter{
+				Name:        "from",
+				Description: "The unit of the temperature.",
+			},
+			function.StringParameter{
+				Name:        "to",
+				Description: "The unit to convert the temperature to.",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *convertTemperatureFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value float64
+	var from, to string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value, &from, &to))
+	if resp.Error != nil {
+		return
+	}
+
+	for argument, unit := range []string{from, to} {
+		if _, err := dtapi.ParseTemperatureUnit(unit); err != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(int64(argument+1), err.Error()))
+		}
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	converted, err := dtapi.ConvertTemperature(value, from, to)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, converted))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &dataConnectorResource{}
+	_ list.ListResourceWithConfigure = &dataConnectorResource{}
+)
+
+// NewDataConnectorListResource is a helper function to simplify the provider implementation.
+func NewDataConnectorListResource() list.ListResource {
+	return &dataConnectorResource{}
+}
+
+type dataConnectorListModel struct {
+	ProjectID   types.String `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+// ListResourceConfigSchema defines the filters for listing data connectors.
+func (r *dataConnectorResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the data connectors in a project, optionally filtered by display name. There is no labels filter, as the labels of a data connector are only the label keys forwarded with its events.",
+		Attributes: map[string]listschema.Attribute{
+			"project_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the project to list data connectors in. Defaults to the provider's `default_project`.",
+			},
+			"display_name": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list data connectors with this display name.",
+			},
+		},
+	}
+}
+
+// List lists the data connectors in a project.
+func (r *dataConnectorResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	if r.client == nil {
+		resp.Results = list.ListResultsStreamDiagnostics(unconfiguredClientDiagnostics())
+		return
+	}
+
+	var config dataConnectorListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	projectID, diags := listConfigDefault(config.ProjectID, path.Root("project_id"), "default_project", r.client.DefaultProject)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	dataConnectors, err := r.client.ListDataConnectors(ctx, projectID)
+	if err != nil {
+		diags.AddError("Error listing data connectors", "Could not list data connectors: "+err.Error())
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var matches []dtapi.DataConnector
+	for _, dataConnector := range dataConnectors {
+		if matchesListFilter(config.DisplayName, nil, dataConnector.DisplayName, nil) {
+			matches = append(matches, dataConnector)
+		}
+	}
+
+	resp.Results = listResults(req, matches, func(dataConnector dtapi.DataConnector) list.ListResult {
+		state, diags := dataConnectorToState(ctx, dataConnector)
+		return newListResult(ctx, req, dataConnector.DisplayName, state.Name, &dataConnectorIdentityModel{}, &state, diags)
+	})
+}
@@ -6,25 +6,32 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/disruptive-technologies/terraform-provider-dt/internal/dt"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &dataConnectorResource{}
-	_ resource.ResourceWithConfigure   = &dataConnectorResource{}
-	_ resource.ResourceWithImportState = &dataConnectorResource{}
+	_ resource.Resource                   = &dataConnectorResource{}
+	_ resource.ResourceWithConfigure      = &dataConnectorResource{}
+	_ resource.ResourceWithModifyPlan     = &dataConnectorResource{}
+	_ resource.ResourceWithValidateConfig = &dataConnectorResource{}
+	_ resource.ResourceWithImportState    = &dataConnectorResource{}
+	_ resource.ResourceWithIdentity       = &dataConnectorResource{}
 )
 
 // NewDataConnectorResource is a helper function to simplify the provider implementation.
@@ -34,17 +41,55 @@ func NewDataConnectorResource() resource.Resource {
 
 // dataConnectorResource is the resource implementation.
 type dataConnectorResource struct {
-	client *dt.Client
+	client *providerClient
 }
 
+// dataConnectorReplaceAttributes are the attributes that require replacement
+// of the data connector when they change.
+var dataConnectorReplaceAttributes = path.Paths{path.Root("type"), path.Root("project")}
+
 // Metadata returns the resource type name.
 func (r *dataConnectorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_data_connector"
 }
 
 func (r *dataConnectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	// Import by the resource name, {project_id}/{display_name}, or by the identity in an import block
+	importStateByNameOrIdentity(ctx, req, resp, &dataConnectorIdentityModel{}, r.resolveImportID)
+}
+
+// resolveImportID resolves an import ID on the form {project_id}/{display_name} to the name of the data connector.
+func (r *dataConnectorResource) resolveImportID(ctx context.Context, id string) (string, error) {
+	parts, err := splitImportID(id, "project_id", "display_name")
+	if err != nil {
+		return "", err
+	}
+	dataConnectors, err := r.client.ListDataConnectors(ctx, parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	candidates := make([]importCandidate, 0, len(dataConnectors))
+	for _, dataConnector := range dataConnectors {
+		candidates = append(candidates, importCandidate{name: dataConnector.Name, key: dataConnector.DisplayName})
+	}
+	return matchImportID("data connector", parts[1], candidates)
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *dataConnectorResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"project_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the project the data connector belongs to.",
+			},
+			"data_connector_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the data connector.",
+			},
+		},
+	}
 }
 
 // Schema defines the schema for the resource.
@@ -76,10 +121,11 @@ func (r *dataConnectorResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"project": schema.StringAttribute{
-				Required:    true,
-				Description: "The resource name of the project that the data connector belongs to. On the form `projects/{project_id}`.",
+				Optional:    true,
+				Computed:    true,
+				Description: "The resource name of the project that the data connector belongs to. On the form `projects/{project_id}`. Defaults to the provider's `default_project`.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"status": schema.StringAttribute{
@@ -101,6 +147,7 @@ func (r *dataConnectorResource) Schema(ctx context.Context, req resource.SchemaR
 				Default:     listdefault.StaticValue(labelDefault),
 				Computed:    true,
 			},
+			"deletion_protection": deletionProtectionAttribute("data connector"),
 			"http_config": schema.SingleNestedAttribute{
 				Optional:    true,
 				Description: "HTTP configuration for the connector.",
@@ -115,6 +162,23 @@ func (r *dataConnectorResource) Schema(ctx context.Context, req resource.SchemaR
 						DeprecationMessage: "The use of signature secret is deprecated, use DT-Asymmetric-Signature to validate the payload instead.",
 						Sensitive:          true,
 					},
+					"signature_secret_wo": schema.StringAttribute{
+						Optional:  true,
+						WriteOnly: true,
+						Sensitive: true,
+						Description: "Secret used to sign the payload, as a write-only attribute that is never stored in the plan or state. " +
+							"Requires Terraform 1.11 or later. Bump `signature_secret_wo_version` to send a changed secret.",
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("signature_secret")),
+						},
+					},
+					"signature_secret_wo_version": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The version of `signature_secret_wo`. Changing it sends the current value of `signature_secret_wo` to DT.",
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("signature_secret_wo")),
+						},
+					},
 					"headers": schema.MapAttribute{
 						Optional:    true,
 						ElementType: types.StringType,
@@ -224,6 +288,7 @@ type dataConnectorResourceModel struct {
 	Status                types.String           `tfsdk:"status"`
 	Events                types.List             `tfsdk:"events"`
 	Labels                types.List             `tfsdk:"labels"`
+	DeletionProtection    types.Bool             `tfsdk:"deletion_protection"`
 	HTTPConfig            *httpConfig            `tfsdk:"http_config"`
 	AzureServiceBusConfig *azureServiceBusConfig `tfsdk:"azure_service_bus_config"`
 	AzureEventHubConfig   *azureEventHubConfig   `tfsdk:"azure_event_hub_config"`
@@ -231,10 +296,32 @@ type dataConnectorResourceModel struct {
 	AWSSQSConfig          *awsSQSConfig          `tfsdk:"aws_sqs_config"`
 }
 
+// dataConnectorIdentityModel is the identity of the resource.
+type dataConnectorIdentityModel struct {
+	ProjectID       types.String `tfsdk:"project_id"`
+	DataConnectorID types.String `tfsdk:"data_connector_id"`
+}
+
+func (m *dataConnectorIdentityModel) name() string {
+	return dtapi.DataConnectorName{ProjectID: m.ProjectID.ValueString(), DataConnectorID: m.DataConnectorID.ValueString()}.String()
+}
+
+func (m *dataConnectorIdentityModel) fromName(name string) error {
+	dataConnectorName, err := dtapi.ParseDataConnectorName(name)
+	if err != nil {
+		return err
+	}
+	m.ProjectID = types.StringValue(dataConnectorName.ProjectID)
+	m.DataConnectorID = types.StringValue(dataConnectorName.DataConnectorID)
+	return nil
+}
+
 type httpConfig struct {
-	URL             types.String `tfsdk:"url"`
-	SignatureSecret types.String `tfsdk:"signature_secret"`
-	Headers         types.Map    `tfsdk:"headers"`
+	URL                      types.String `tfsdk:"url"`
+	SignatureSecret          types.String `tfsdk:"signature_secret"`
+	SignatureSecretWO        types.String `tfsdk:"signature_secret_wo"`
+	SignatureSecretWOVersion types.Int64  `tfsdk:"signature_secret_wo_version"`
+	Headers                  types.Map    `tfsdk:"headers"`
 }
 
 type azureServiceBusConfig struct {
@@ -278,6 +365,14 @@ func (r *dataConnectorResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	// Write-only secrets are only available in the config
+	var config dataConnectorResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	dataConnectorSecretsFromConfig(&plan, config)
+
 	toBeCreated, diags := stateToDataConnector(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -296,6 +391,8 @@ func (r *dataConnectorResource) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	dataConnectorSecretsToState(&state, plan)
+	state.DeletionProtection = deletionProtectionFromPrior(plan.DeletionProtection)
 
 	// Set the Terraform state
 	diags = resp.State.Set(ctx, &state)
@@ -303,6 +400,7 @@ func (r *dataConnectorResource) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &dataConnectorIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -322,11 +420,14 @@ func (r *dataConnectorResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	prior := state
 	state, diags = dataConnectorToState(ctx, dataConnector)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	dataConnectorSecretsToState(&state, prior)
+	state.DeletionProtection = deletionProtectionFromPrior(prior.DeletionProtection)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -334,6 +435,7 @@ func (r *dataConnectorResource) Read(ctx context.Context, req resource.ReadReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &dataConnectorIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -346,6 +448,11 @@ func (r *dataConnectorResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, "dt_data_connector", "destroyed")
+		return
+	}
+
 	// Delete the data connector
 	err := r.client.DeleteDataConnector(ctx, state.Name.ValueString())
 	if err != nil {
@@ -364,6 +471,14 @@ func (r *dataConnectorResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	// Write-only secrets are only available in the config
+	var config dataConnectorResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	dataConnectorSecretsFromConfig(&plan, config)
+
 	dataConnector, diags := stateToDataConnector(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -379,6 +494,8 @@ func (r *dataConnectorResource) Update(ctx context.Context, req resource.UpdateR
 
 	state, diag := dataConnectorToState(ctx, dataConnector)
 	resp.Diagnostics.Append(diag...)
+	dataConnectorSecretsToState(&state, plan)
+	state.DeletionProtection = deletionProtectionFromPrior(plan.DeletionProtection)
 
 	// Set the Terraform state
 	diags = resp.State.Set(ctx, &state)
@@ -386,6 +503,33 @@ func (r *dataConnectorResource) Update(ctx context.Context, req resource.UpdateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &dataConnectorIdentityModel{}, state.Name, &resp.Diagnostics)
+}
+
+// ValidateConfig checks the display name and label keys against the provider policy.
+func (r *dataConnectorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if r.client == nil || r.client.Policy == nil {
+		return
+	}
+	validateDisplayNamePolicy(ctx, r.client.Policy, req.Config, &resp.Diagnostics)
+	validateLabelKeysPolicy(ctx, r.client.Policy, req.Config, path.Root("labels"), &resp.Diagnostics)
+}
+
+// ModifyPlan fills in the provider's default project when project is not set.
+// Any planned change fails when the provider is read only, and destroying or
+// replacing a protected data connector fails.
+func (r *dataConnectorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkReadOnly(ctx, r.client, "dt_data_connector", dataConnectorReplaceAttributes, req, resp)
+	defer checkDeletionProtection(ctx, "dt_data_connector", dataConnectorReplaceAttributes, req, resp)
+
+	if r.client == nil {
+		return
+	}
+	var project string
+	if r.client.DefaultProject != "" {
+		project = dtapi.ProjectName{ProjectID: r.client.DefaultProject}.String()
+	}
+	planProviderDefault(ctx, req, resp, path.Root("project"), "default_project", project, true)
 }
 
 // Configure adds the provider configured client to the resource.
@@ -396,7 +540,7 @@ func (r *dataConnectorResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*dt.Client)
+	client, ok := req.ProviderData.(*providerClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -410,7 +554,7 @@ func (r *dataConnectorResource) Configure(ctx context.Context, req resource.Conf
 }
 
 // stateToDataConnector converts the resource model to the API model.
-func stateToDataConnector(ctx context.Context, plan dataConnectorResourceModel) (dt.DataConnector, diag.Diagnostics) {
+func stateToDataConnector(ctx context.Context, plan dataConnectorResourceModel) (dtapi.DataConnector, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	events, d := expandStringList(ctx, plan.Events)
@@ -419,15 +563,15 @@ func stateToDataConnector(ctx context.Context, plan dataConnectorResourceModel)
 	labels, d := expandStringList(ctx, plan.Labels)
 	diags = append(diags, d...)
 
-	var httpPushConfig *dt.HTTPConfig
-	var azureServiceBusConfig *dt.AzureServiceBusConfig
-	var azureEventHubConfig *dt.AzureEventHubConfig
-	var pubsubConfig *dt.PubsubConfig
-	var awsSQSConfig *dt.AWSSQSConfig
+	var httpPushConfig *dtapi.HTTPConfig
+	var azureServiceBusConfig *dtapi.AzureServiceBusConfig
+	var azureEventHubConfig *dtapi.AzureEventHubConfig
+	var pubsubConfig *dtapi.PubsubConfig
+	var awsSQSConfig *dtapi.AWSSQSConfig
 
 	diags = append(diags, validateTypeConfig(plan)...)
 
-	dataConnector := dt.DataConnector{
+	dataConnector := dtapi.DataConnector{
 		Name:        plan.Name.ValueString(),
 		DisplayName: plan.DisplayName.ValueString(),
 		Type:        plan.Type.ValueString(),
@@ -441,41 +585,41 @@ func stateToDataConnector(ctx context.Context, plan dataConnectorResourceModel)
 		for key, value := range plan.HTTPConfig.Headers.Elements() {
 			headersMap[key] = value.String()
 		}
-		httpPushConfig = &dt.HTTPConfig{
+		httpPushConfig = &dtapi.HTTPConfig{
 			Url:             plan.HTTPConfig.URL.ValueString(),
-			SignatureSecret: plan.HTTPConfig.SignatureSecret.ValueString(),
+			SignatureSecret: writeOnlyOr(plan.HTTPConfig.SignatureSecretWO, plan.HTTPConfig.SignatureSecret),
 			Headers:         headersMap,
 		}
 		dataConnector.HTTPConfig = httpPushConfig
 	case "AZURE_SERVICE_BUS":
-		azureServiceBusConfig = &dt.AzureServiceBusConfig{
+		azureServiceBusConfig = &dtapi.AzureServiceBusConfig{
 			URL: plan.AzureServiceBusConfig.URL.ValueString(),
-			AuthenticationConfig: dt.AuthenticationConfig{
+			AuthenticationConfig: dtapi.AuthenticationConfig{
 				TenantID: plan.AzureServiceBusConfig.AuthenticationConfig.TenantID.ValueString(),
 				ClientID: plan.AzureServiceBusConfig.AuthenticationConfig.ClientID.ValueString(),
 			},
-			BrokerProperties: dt.BrokerProperties{
+			BrokerProperties: dtapi.BrokerProperties{
 				CorrelationID: plan.AzureServiceBusConfig.BrokerProperties.CorrelationID.ValueString(),
 			},
 		}
 		dataConnector.AzureServiceBusConfig = azureServiceBusConfig
 	case "AZURE_EVENT_HUB":
-		azureEventHubConfig = &dt.AzureEventHubConfig{
+		azureEventHubConfig = &dtapi.AzureEventHubConfig{
 			URL: plan.AzureEventHubConfig.URL.ValueString(),
-			AuthenticationConfig: dt.AuthenticationConfig{
+			AuthenticationConfig: dtapi.AuthenticationConfig{
 				TenantID: plan.AzureEventHubConfig.AuthenticationConfig.TenantID.ValueString(),
 				ClientID: plan.AzureEventHubConfig.AuthenticationConfig.ClientID.ValueString(),
 			},
 		}
 		dataConnector.AzureEventHubConfig = azureEventHubConfig
 	case "GOOGLE_CLOUD_PUBSUB":
-		pubsubConfig = &dt.PubsubConfig{
+		pubsubConfig = &dtapi.PubsubConfig{
 			Topic:    plan.PubsubConfig.Topic.ValueString(),
 			Audience: plan.PubsubConfig.Audience.ValueString(),
 		}
 		dataConnector.PubsubConfig = pubsubConfig
 	case "AWS_SQS":
-		awsSQSConfig = &dt.AWSSQSConfig{
+		awsSQSConfig = &dtapi.AWSSQSConfig{
 			QueueUrl:   plan.AWSSQSConfig.QueueURL.ValueString(),
 			AwsRoleArn: plan.AWSSQSConfig.AWSRoleArn.ValueString(),
 			Audience:   plan.AWSSQSConfig.Audience.ValueString(),
@@ -494,8 +638,29 @@ func stateToDataConnector(ctx context.Context, plan dataConnectorResourceModel)
 	return dataConnector, diags
 }
 
+// dataConnectorSecretsFromConfig copies the write-only signature secret from the
+// config, as it is always null in the plan.
+func dataConnectorSecretsFromConfig(plan *dataConnectorResourceModel, config dataConnectorResourceModel) {
+	if plan.HTTPConfig != nil && config.HTTPConfig != nil {
+		plan.HTTPConfig.SignatureSecretWO = config.HTTPConfig.SignatureSecretWO
+	}
+}
+
+// dataConnectorSecretsToState keeps the signature secret returned by the API out
+// of the state when it is not set in the prior plan or state, such as when the
+// write-only attribute is used, and carries over the secret version.
+func dataConnectorSecretsToState(state *dataConnectorResourceModel, prior dataConnectorResourceModel) {
+	if state.HTTPConfig == nil || prior.HTTPConfig == nil {
+		return
+	}
+	state.HTTPConfig.SignatureSecretWOVersion = prior.HTTPConfig.SignatureSecretWOVersion
+	if prior.HTTPConfig.SignatureSecret.IsNull() {
+		state.HTTPConfig.SignatureSecret = types.StringNull()
+	}
+}
+
 // dataConnectorToState converts the API model to the resource model.
-func dataConnectorToState(ctx context.Context, dataConnector dt.DataConnector) (dataConnectorResourceModel, diag.Diagnostics) {
+func dataConnectorToState(ctx context.Context, dataConnector dtapi.DataConnector) (dataConnectorResourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	labelsList, d := flattenStringListToAttr(ctx, dataConnector.Labels)
 	diags = append(diags, d...)
@@ -504,13 +669,14 @@ func dataConnectorToState(ctx context.Context, dataConnector dt.DataConnector) (
 	diags = append(diags, d...)
 
 	resourceModel := dataConnectorResourceModel{
-		Name:        types.StringValue(dataConnector.Name),
-		DisplayName: types.StringValue(dataConnector.DisplayName),
-		Project:     types.StringValue(dataConnector.ProjectID()),
-		Type:        types.StringValue(dataConnector.Type),
-		Status:      types.StringValue(dataConnector.Status),
-		Events:      eventsList,
-		Labels:      labelsList,
+		Name:               types.StringValue(dataConnector.Name),
+		DisplayName:        types.StringValue(dataConnector.DisplayName),
+		Project:            types.StringValue(dataConnector.ProjectID()),
+		Type:               types.StringValue(dataConnector.Type),
+		Status:             types.StringValue(dataConnector.Status),
+		Events:             eventsList,
+		Labels:             labelsList,
+		DeletionProtection: types.BoolValue(false),
 	}
 
 	switch dataConnector.Type {
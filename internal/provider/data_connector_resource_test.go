@@ -3,8 +3,14 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -13,7 +19,7 @@ import (
 // There can only be 10 data connectors per project.
 var dataConnectorProviderConfig = providerConfig + `
 resource "dt_project" "test" {
-	display_name = "data connector Acceptance Test Project"
+	display_name = "Acceptance Test data connector project"
 	organization = "organizations/cvinmt9aq9sc738g6eog"
 	location = {
 		time_location = "Europe/Oslo"
@@ -31,7 +37,7 @@ func TestAccSafeDataConnectorProvider(t *testing.T) {
 			{
 				Config: dataConnectorProviderConfig + `
 				resource "dt_data_connector" "test" {
-					display_name = "data connector Acceptance Test"
+					display_name = "Acceptance Test data connector"
 					type = "GOOGLE_CLOUD_PUBSUB"
 					project = dt_project.test.id
 					pubsub_config = {
@@ -42,7 +48,7 @@ func TestAccSafeDataConnectorProvider(t *testing.T) {
 				
 				`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_data_connector.test", "display_name", "data connector Acceptance Test"),
+					resource.TestCheckResourceAttr("dt_data_connector.test", "display_name", "Acceptance Test data connector"),
 					resource.TestCheckResourceAttr("dt_data_connector.test", "type", "GOOGLE_CLOUD_PUBSUB"),
 					resource.TestCheckResourceAttr("dt_data_connector.test", "pubsub_config.topic", "projects/your-project-id/topics/your-topic"),
 					resource.TestCheckResourceAttr("dt_data_connector.test", "pubsub_config.audience", "//iam.googleapis.com/projects/12345689/locations/europe-west1/workloadIdentityPools/my-pool-id/providers/my-provider-id"),
@@ -63,3 +69,157 @@ func TestAccSafeDataConnectorProvider(t *testing.T) {
 		},
 	})
 }
+
+func TestDataConnectorList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		case "/v2/projects/p1/dataconnectors":
+			fmt.Fprint(w, `{"dataConnectors":[
+				{"name":"projects/p1/dataconnectors/dc1","type":"HTTP_PUSH","displayName":"Webhook","status":"ACTIVE","events":[],"labels":[],"httpConfig":{"url":"https://example.com","headers":{}}},
+				{"name":"projects/p1/dataconnectors/dc2","type":"HTTP_PUSH","displayName":"Archive","status":"USER_DISABLED","events":[],"labels":[],"httpConfig":{"url":"https://example.com/archive","headers":{}}}
+			]}`)
+		case "/v2/projects/p2/dataconnectors":
+			fmt.Fprint(w, `{"dataConnectors":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := map[string]struct {
+		config map[string]tftypes.Value
+		limit  int64
+		want   []string
+	}{
+		"default project": {want: []string{"Webhook", "Archive"}},
+		"project": {
+			config: map[string]tftypes.Value{"project_id": tftypes.NewValue(tftypes.String, "p2")},
+		},
+		"display name": {
+			config: map[string]tftypes.Value{"display_name": tftypes.NewValue(tftypes.String, "Archive")},
+			want:   []string{"Archive"},
+		},
+		"limit": {limit: 1, want: []string{"Webhook"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := listResource(t, "dt_data_connector", testServerProviderConfig(t, server.URL), tt.config, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDataConnectorWriteOnlySecrets(t *testing.T) {
+	t.Parallel()
+
+	httpPush := func(secret, secretWO types.String, version types.Int64) dataConnectorResourceModel {
+		return dataConnectorResourceModel{HTTPConfig: &httpConfig{
+			SignatureSecret:          secret,
+			SignatureSecretWO:        secretWO,
+			SignatureSecretWOVersion: version,
+		}}
+	}
+
+	// the write-only secret is only present in the config
+	plan := httpPush(types.StringNull(), types.StringNull(), types.Int64Value(2))
+	dataConnectorSecretsFromConfig(&plan, httpPush(types.StringNull(), types.StringValue("from-vault"), types.Int64Value(2)))
+	if got := writeOnlyOr(plan.HTTPConfig.SignatureSecretWO, plan.HTTPConfig.SignatureSecret); got != "from-vault" {
+		t.Errorf("expected the write-only secret from the config, got %q", got)
+	}
+
+	// the secret returned by the API is kept out of the state, and the version is kept
+	state := httpPush(types.StringValue("from-vault"), types.StringNull(), types.Int64Null())
+	dataConnectorSecretsToState(&state, plan)
+	if !state.HTTPConfig.SignatureSecret.IsNull() || !state.HTTPConfig.SignatureSecretWO.IsNull() {
+		t.Errorf("expected no secrets in the state, got %s and %s", state.HTTPConfig.SignatureSecret, state.HTTPConfig.SignatureSecretWO)
+	}
+	if !state.HTTPConfig.SignatureSecretWOVersion.Equal(types.Int64Value(2)) {
+		t.Errorf("expected version 2, got %s", state.HTTPConfig.SignatureSecretWOVersion)
+	}
+
+	// a secret set with the sensitive attribute is still stored
+	state = httpPush(types.StringValue("secret"), types.StringNull(), types.Int64Null())
+	dataConnectorSecretsToState(&state, httpPush(types.StringValue("secret"), types.StringNull(), types.Int64Null()))
+	if got := state.HTTPConfig.SignatureSecret; !got.Equal(types.StringValue("secret")) {
+		t.Errorf("expected the secret to be kept, got %s", got)
+	}
+}
+
+func TestDataConnectorWriteOnlySecretVersion(t *testing.T) {
+	t.Parallel()
+
+	httpConfigType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"url":                         tftypes.String,
+		"signature_secret":            tftypes.String,
+		"signature_secret_wo":         tftypes.String,
+		"signature_secret_wo_version": tftypes.Number,
+		"headers":                     tftypes.Map{ElementType: tftypes.String},
+	}}
+	httpPush := func(secretWO interface{}, version int64) map[string]tftypes.Value {
+		return map[string]tftypes.Value{"http_config": tftypes.NewValue(httpConfigType, map[string]tftypes.Value{
+			"url":                         tftypes.NewValue(tftypes.String, "https://example.com"),
+			"signature_secret":            tftypes.NewValue(tftypes.String, nil),
+			"signature_secret_wo":         tftypes.NewValue(tftypes.String, secretWO),
+			"signature_secret_wo_version": tftypes.NewValue(tftypes.Number, version),
+			"headers":                     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{}),
+		})}
+	}
+	prior := dataConnectorState(false, httpPush(nil, 1))
+
+	tests := map[string]struct {
+		config     map[string]tftypes.Value
+		wantUpdate bool
+	}{
+		// Terraform doesn't store the write-only secret, so a changed secret
+		// alone isn't a change and isn't sent.
+		"changed secret": {config: dataConnectorConfig(false, httpPush("rotated", 1))},
+		"bumped version": {config: dataConnectorConfig(false, httpPush("rotated", 2)), wantUpdate: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			resp := planResourceChange(t, "dt_data_connector", nil, prior, tt.config)
+			if hasErrorDiagnostic(resp.Diagnostics) {
+				t.Fatalf("unexpected diagnostics: %v", diagnosticSummaries(resp.Diagnostics))
+			}
+
+			objectType := dataConnectorObjectType(t)
+			planned, err := resp.PlannedState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatalf("failed to unmarshal the planned state: %v", err)
+			}
+			priorValue, err := dynamicValue(t, objectType, prior).Unmarshal(objectType)
+			if err != nil {
+				t.Fatalf("failed to unmarshal the prior state: %v", err)
+			}
+			if got := !planned.Equal(priorValue); got != tt.wantUpdate {
+				t.Errorf("expected update %t, got planned state %s", tt.wantUpdate, planned)
+			}
+
+			secretWO, _, err := tftypes.WalkAttributePath(planned, tftypes.NewAttributePath().WithAttributeName("http_config").WithAttributeName("signature_secret_wo"))
+			if err != nil {
+				t.Fatalf("failed to get the write-only secret: %v", err)
+			}
+			if !secretWO.(tftypes.Value).IsNull() {
+				t.Errorf("expected no write-only secret in the plan, got %s", secretWO)
+			}
+		})
+	}
+}
+
+// dataConnectorObjectType returns the type of the dt_data_connector schema.
+func dataConnectorObjectType(t *testing.T) tftypes.Type {
+	t.Helper()
+
+	_, schemaResp := newProviderServer(t, nil)
+	return schemaResp.ResourceSchemas["dt_data_connector"].ValueType()
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &dataConnectorSyncAction{}
+	_ action.ActionWithConfigure = &dataConnectorSyncAction{}
+)
+
+// NewDataConnectorSyncAction is a helper function to simplify the provider implementation.
+func NewDataConnectorSyncAction() action.Action {
+	return &dataConnectorSyncAction{}
+}
+
+// dataConnectorSyncAction is the action implementation.
+type dataConnectorSyncAction struct {
+	client *providerClient
+}
+
+// dataConnectorSyncActionModel is the data model for the action.
+type dataConnectorSyncActionModel struct {
+	DataConnector types.String `tfsdk:"data_connector"`
+}
+
+// Metadata returns the action type name.
+func (a *dataConnectorSyncAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_connector_sync"
+}
+
+// Schema defines the schema for the action.
+func (a *dataConnectorSyncAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sends the most recent events of all devices in the project through a data connector, " +
+			"to test that the receiving end gets and accepts them.",
+		Attributes: map[string]schema.Attribute{
+			"data_connector": schema.StringAttribute{
+				Required:    true,
+				Description: "The resource name of the data connector on the form `projects/{project_id}/dataconnectors/{data_connector_id}`, such as `dt_data_connector.webhook.name`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^projects/[^/]+/dataconnectors/[^/]+$`), "must be on the form projects/{project_id}/dataconnectors/{data_connector_id}"),
+				},
+			},
+		},
+	}
+}
+
+// Invoke syncs the data connector.
+func (a *dataConnectorSyncAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config dataConnectorSyncActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Sending the most recent events through " + config.DataConnector.ValueString(),
+	})
+	if err := a.client.SyncDataConnector(ctx, config.DataConnector.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error syncing data connector", "Could not sync data connector: "+err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *dataConnectorSyncAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"invalid provider data",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
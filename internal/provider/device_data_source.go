@@ -5,11 +5,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
-	"github.com/disruptive-technologies/terraform-provider-dt/internal/dt"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -24,7 +24,7 @@ func NewDeviceDataSource() datasource.DataSource {
 }
 
 type deviceDataSource struct {
-	client dt.Client
+	client providerClient
 }
 
 // Metadata returns the data source type name.
@@ -84,25 +84,12 @@ func (d deviceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	deviceID, projectID, err := idFromName(device.Name)
-	if err != nil {
-		resp.Diagnostics.AddError("failed to get device ID and project ID", err.Error())
-		return
-	}
-	labels, diag := types.MapValueFrom(ctx, types.StringType, device.Labels)
+	state, diag := deviceToState(ctx, *device)
 	resp.Diagnostics.Append(diag...)
 	if diag.HasError() {
 		return
 	}
 
-	state := DeviceDataSourceModel{
-		DeviceID:  types.StringValue(deviceID),
-		ProjectID: types.StringValue(projectID),
-		Name:      types.StringValue(device.Name),
-		Type:      types.StringValue(device.Type),
-		Labels:    labels,
-	}
-
 	diag = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diag...)
 	if diag.HasError() {
@@ -117,11 +104,11 @@ func (d *deviceDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*dt.Client)
+	client, ok := req.ProviderData.(*providerClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"invalid provider data",
-			fmt.Sprintf("Expected *dt.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -129,10 +116,24 @@ func (d *deviceDataSource) Configure(ctx context.Context, req datasource.Configu
 	d.client = *client
 }
 
-func idFromName(name string) (string, string, error) {
-	parts := strings.Split(name, "/")
-	if len(parts) != 4 {
-		return name, "", fmt.Errorf("invalid device name: %s", name)
+// deviceToState converts a device to the data source model.
+func deviceToState(ctx context.Context, device dtapi.Device) (DeviceDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	deviceName, err := dtapi.ParseDeviceName(device.Name)
+	if err != nil {
+		diags.AddError("failed to get device ID and project ID", err.Error())
+		return DeviceDataSourceModel{}, diags
 	}
-	return parts[len(parts)-1], parts[len(parts)-3], nil
+	labels, diags := types.MapValueFrom(ctx, types.StringType, device.Labels)
+	if diags.HasError() {
+		return DeviceDataSourceModel{}, diags
+	}
+
+	return DeviceDataSourceModel{
+		DeviceID:  types.StringValue(deviceName.DeviceID),
+		ProjectID: types.StringValue(deviceName.ProjectID),
+		Name:      types.StringValue(device.Name),
+		Type:      types.StringValue(device.Type),
+		Labels:    labels,
+	}, diags
 }
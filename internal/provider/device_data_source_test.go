@@ -3,8 +3,13 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -30,3 +35,56 @@ func TestAccSafeDeviceDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestDeviceList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		case "/v2/projects/p1/devices":
+			fmt.Fprint(w, `{"devices":[
+				{"name":"projects/p1/devices/d1","type":"temperature","labels":{"name":"Fridge","room":"kitchen"}},
+				{"name":"projects/p1/devices/d2","type":"temperature","labels":{"name":"Freezer","room":"kitchen"}},
+				{"name":"projects/p1/devices/d3","type":"ccon","labels":{"name":"Cloud Connector","room":"hall"}}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	labelsType := tftypes.Map{ElementType: tftypes.String}
+	tests := map[string]struct {
+		config map[string]tftypes.Value
+		limit  int64
+		want   []string
+	}{
+		"all": {want: []string{"Fridge", "Freezer", "Cloud Connector"}},
+		"labels": {
+			config: map[string]tftypes.Value{
+				"labels": tftypes.NewValue(labelsType, map[string]tftypes.Value{"room": tftypes.NewValue(tftypes.String, "kitchen")}),
+			},
+			want: []string{"Fridge", "Freezer"},
+		},
+		"display name and labels": {
+			config: map[string]tftypes.Value{
+				"display_name": tftypes.NewValue(tftypes.String, "Freezer"),
+				"labels":       tftypes.NewValue(labelsType, map[string]tftypes.Value{"room": tftypes.NewValue(tftypes.String, "kitchen")}),
+			},
+			want: []string{"Freezer"},
+		},
+		"limit": {limit: 1, want: []string{"Fridge"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := listResource(t, "dt_device", testServerProviderConfig(t, server.URL), tt.config, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource                 = &deviceListResource{}
+	_ list.ListResourceWithConfigure    = &deviceListResource{}
+	_ list.ListResourceWithRawV6Schemas = &deviceListResource{}
+)
+
+// NewDeviceListResource is a helper function to simplify the provider implementation.
+func NewDeviceListResource() list.ListResource {
+	return &deviceListResource{}
+}
+
+// deviceListResource lists devices. Devices aren't managed by Terraform, so
+// there is no dt_device resource, and the schemas of the listed devices are
+// supplied by RawV6Schemas instead. They match the dt_device data source.
+type deviceListResource struct {
+	client *providerClient
+}
+
+type deviceListModel struct {
+	ProjectID   types.String `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Labels      types.Map    `tfsdk:"labels"`
+}
+
+// Metadata returns the list resource type name.
+func (r *deviceListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device"
+}
+
+// RawV6Schemas defines the schema and identity of the listed devices.
+func (r *deviceListResource) RawV6Schemas(_ context.Context, _ list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	resp.ProtoV6Schema = &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "A sensor or cloud connector.",
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{Name: "device_id", Type: tftypes.String, Computed: true, Description: "The resource ID of the device."},
+				{Name: "project_id", Type: tftypes.String, Computed: true, Description: "The resource ID of the project."},
+				{Name: "name", Type: tftypes.String, Computed: true, Description: "The resource name of the device. On the form `projects/{project_id}/devices/{device_id}`."},
+				{Name: "type", Type: tftypes.String, Computed: true, Description: "The type of the device."},
+				{Name: "labels", Type: tftypes.Map{ElementType: tftypes.String}, Computed: true, Description: "The labels of the device."},
+			},
+		},
+	}
+	resp.ProtoV6IdentitySchema = &tfprotov6.ResourceIdentitySchema{
+		IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+			{Name: "project_id", Type: tftypes.String, RequiredForImport: true, Description: "The ID of the project the device belongs to."},
+			{Name: "device_id", Type: tftypes.String, RequiredForImport: true, Description: "The ID of the device."},
+		},
+	}
+}
+
+// ListResourceConfigSchema defines the filters for listing devices.
+func (r *deviceListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the devices in a project, optionally filtered by display name and labels. Devices aren't managed by Terraform, so the results can't be imported.",
+		Attributes: map[string]listschema.Attribute{
+			"project_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the project to list devices in. Defaults to the provider's `default_project`.",
+			},
+			"display_name": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list devices with this display name, the `name` label.",
+			},
+			"labels": listschema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list devices that have all of these labels.",
+			},
+		},
+	}
+}
+
+// List lists the devices in a project.
+func (r *deviceListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	if r.client == nil {
+		resp.Results = list.ListResultsStreamDiagnostics(unconfiguredClientDiagnostics())
+		return
+	}
+
+	var config deviceListModel
+	diags := req.Config.Get(ctx, &config)
+	labels := make(map[string]string)
+	if !config.Labels.IsNull() {
+		diags.Append(config.Labels.ElementsAs(ctx, &labels, false)...)
+	}
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	projectID, diags := listConfigDefault(config.ProjectID, path.Root("project_id"), "default_project", r.client.DefaultProject)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	devices, err := r.client.ListDevices(ctx, projectID)
+	if err != nil {
+		diags.AddError("Error listing devices", "Could not list devices: "+err.Error())
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var matches []dtapi.Device
+	for _, device := range devices {
+		if matchesListFilter(config.DisplayName, labels, device.Labels["name"], device.Labels) {
+			matches = append(matches, device)
+		}
+	}
+
+	resp.Results = listResults(req, matches, func(device dtapi.Device) list.ListResult {
+		state, diags := deviceToState(ctx, device)
+		return newListResult(ctx, req, device.Labels["name"], state.Name, &emulatorIdentityModel{}, &state, diags)
+	})
+}
+
+func (r *deviceListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"invalid provider data",
+			"Provider data is not of the expected type",
+		)
+		return
+	}
+
+	r.client = client
+}
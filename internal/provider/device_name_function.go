@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &deviceNameFunction{}
+
+// NewDeviceNameFunction is a helper function to simplify the provider implementation.
+func NewDeviceNameFunction() function.Function {
+	return &deviceNameFunction{}
+}
+
+// deviceNameFunction is the function implementation.
+type deviceNameFunction struct{}
+
+func (f *deviceNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "device_name"
+}
+
+func (f *deviceNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the resource name of a device",
+		MarkdownDescription: "Returns the resource name of a device: `projects/{project_id}/devices/{device_id}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "project_id",
+				Description: "The ID of the project the device belongs to.",
+			},
+			function.StringParameter{
+				Name:        "device_id",
+				Description: "The ID of the device.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *deviceNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var projectID, deviceID string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &projectID, &deviceID))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := dtapi.ValidateID(projectID); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid project ID: "+err.Error())
+		return
+	}
+	if err := dtapi.ValidateID(deviceID); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "invalid device ID: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, dtapi.DeviceName{ProjectID: projectID, DeviceID: deviceID}.String()))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &emulatorResource{}
+	_ list.ListResourceWithConfigure = &emulatorResource{}
+)
+
+// NewEmulatorListResource is a helper function to simplify the provider implementation.
+func NewEmulatorListResource() list.ListResource {
+	return &emulatorResource{}
+}
+
+type emulatorListModel struct {
+	ProjectID   types.String `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Labels      types.Map    `tfsdk:"labels"`
+}
+
+// ListResourceConfigSchema defines the filters for listing emulators.
+func (r *emulatorResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the emulators in a project, optionally filtered by display name and labels.",
+		Attributes: map[string]listschema.Attribute{
+			"project_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the project to list emulators in. Defaults to the provider's `default_project`.",
+			},
+			"display_name": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list emulators with this display name.",
+			},
+			"labels": listschema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list emulators that have all of these labels.",
+			},
+		},
+	}
+}
+
+// List lists the emulators in a project.
+func (r *emulatorResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	if r.client == nil {
+		resp.Results = list.ListResultsStreamDiagnostics(unconfiguredClientDiagnostics())
+		return
+	}
+
+	var config emulatorListModel
+	diags := req.Config.Get(ctx, &config)
+	labels := make(map[string]string)
+	if !config.Labels.IsNull() {
+		diags.Append(config.Labels.ElementsAs(ctx, &labels, false)...)
+	}
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	projectID, diags := listConfigDefault(config.ProjectID, path.Root("project_id"), "default_project", r.client.DefaultProject)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	emulators, err := r.client.ListEmulators(ctx, projectID)
+	if err != nil {
+		diags.AddError("Error listing emulators", "Could not list emulators: "+err.Error())
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var matches []dtapi.Emulator
+	for _, emulator := range emulators {
+		if matchesListFilter(config.DisplayName, labels, emulator.Labels["name"], emulator.Labels) {
+			matches = append(matches, emulator)
+		}
+	}
+
+	resp.Results = listResults(req, matches, func(emulator dtapi.Emulator) list.ListResult {
+		state, diags := emulatorToState(ctx, emulator, r.client.DefaultLabels, types.MapNull(types.StringType))
+		return newListResult(ctx, req, state.DisplayName.ValueString(), state.Name, &emulatorIdentityModel{}, &state, diags)
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action                   = &emulatorPublishEventAction{}
+	_ action.ActionWithConfigure      = &emulatorPublishEventAction{}
+	_ action.ActionWithValidateConfig = &emulatorPublishEventAction{}
+)
+
+// NewEmulatorPublishEventAction is a helper function to simplify the provider implementation.
+func NewEmulatorPublishEventAction() action.Action {
+	return &emulatorPublishEventAction{}
+}
+
+// emulatorPublishEventAction is the action implementation.
+type emulatorPublishEventAction struct {
+	client *providerClient
+}
+
+// emulatorPublishEventActionModel is the data model for the action.
+type emulatorPublishEventActionModel struct {
+	Emulator  types.String `tfsdk:"emulator"`
+	EventType types.String `tfsdk:"event_type"`
+	Data      types.String `tfsdk:"data"`
+}
+
+// Metadata returns the action type name.
+func (a *emulatorPublishEventAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_emulator_publish_event"
+}
+
+// Schema defines the schema for the action.
+func (a *emulatorPublishEventAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Publishes a test event on an emulated device, as if it was sent by a real sensor.",
+		Attributes: map[string]schema.Attribute{
+			"emulator": schema.StringAttribute{
+				Required:    true,
+				Description: "The resource name of the emulator on the form `projects/{project_id}/devices/{device_id}`, such as `dt_emulator.sensor.name`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^projects/[^/]+/devices/[^/]+$`), "must be on the form projects/{project_id}/devices/{device_id}"),
+				},
+			},
+			"event_type": schema.StringAttribute{
+				Required:    true,
+				Description: "The type of the event, such as `temperature`, `touch` or `objectPresent`. Must be an event the emulator type can publish.",
+			},
+			"data": schema.StringAttribute{
+				Optional:    true,
+				Description: "The event as a JSON object, such as `jsonencode({ value = 22.5 })` for a temperature event. Defaults to an empty object, as for touch events.",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the event data is a JSON object.
+func (a *emulatorPublishEventAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var data types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("data"), &data)...)
+	if resp.Diagnostics.HasError() || data.IsNull() || data.IsUnknown() {
+		return
+	}
+	if _, err := eventData(data); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("data"), "Invalid event data", err.Error())
+	}
+}
+
+// Invoke publishes the event.
+func (a *emulatorPublishEventAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config emulatorPublishEventActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := eventData(config.Data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("data"), "Invalid event data", err.Error())
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Publishing %s event on %s", config.EventType.ValueString(), config.Emulator.ValueString()),
+	})
+	if err := a.client.PublishEmulatorEvent(ctx, config.Emulator.ValueString(), config.EventType.ValueString(), data); err != nil {
+		resp.Diagnostics.AddError("Error publishing emulator event", "Could not publish emulator event: "+err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *emulatorPublishEventAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"invalid provider data",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+// eventData returns the event data as JSON, or an empty object when it is not set.
+func eventData(data types.String) (json.RawMessage, error) {
+	if data.IsNull() {
+		return json.RawMessage(`{}`), nil
+	}
+	var object map[string]any
+	if err := json.Unmarshal([]byte(data.ValueString()), &object); err != nil || object == nil {
+		return nil, fmt.Errorf("data must be a JSON object, such as jsonencode({ value = 22.5 })")
+	}
+	return json.RawMessage(data.ValueString()), nil
+}
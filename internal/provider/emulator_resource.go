@@ -6,11 +6,12 @@ import (
 	"context"
 	"strings"
 
-	"github.com/disruptive-technologies/terraform-provider-dt/internal/dt"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
@@ -23,9 +24,12 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &emulatorResource{}
-	_ resource.ResourceWithConfigure   = &emulatorResource{}
-	_ resource.ResourceWithImportState = &emulatorResource{}
+	_ resource.Resource                   = &emulatorResource{}
+	_ resource.ResourceWithConfigure      = &emulatorResource{}
+	_ resource.ResourceWithModifyPlan     = &emulatorResource{}
+	_ resource.ResourceWithValidateConfig = &emulatorResource{}
+	_ resource.ResourceWithImportState    = &emulatorResource{}
+	_ resource.ResourceWithIdentity       = &emulatorResource{}
 )
 
 var (
@@ -52,7 +56,7 @@ func NewEmulatorResource() resource.Resource {
 
 // emulatorResource is the resource implementation.
 type emulatorResource struct {
-	client *dt.Client
+	client *providerClient
 }
 
 func (r *emulatorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,8 +64,24 @@ func (r *emulatorResource) Metadata(_ context.Context, req resource.MetadataRequ
 }
 
 func (r *emulatorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	// Import by the resource name, or by the identity in an import block
+	importStateByNameOrIdentity(ctx, req, resp, &emulatorIdentityModel{}, nil)
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *emulatorResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"project_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the project the emulated device belongs to.",
+			},
+			"device_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the emulated device.",
+			},
+		},
+	}
 }
 
 func (r *emulatorResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -84,8 +104,9 @@ func (r *emulatorResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				Description: "The display name of the emulator.",
 			},
 			"project_id": schema.StringAttribute{
-				Required:    true,
-				Description: "The project ID to create the emulator in.",
+				Optional:    true,
+				Computed:    true,
+				Description: "The project ID to create the emulator in. Defaults to the provider's `default_project`.",
 			},
 			"type": schema.StringAttribute{
 				Required:    true,
@@ -109,6 +130,11 @@ func (r *emulatorResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				Description: "A map of labels to assign to the emulator.",
 				Default:     mapdefault.StaticValue(labelDefault),
 			},
+			"labels_all": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "All labels of the emulator, including the provider's `default_labels`.",
+			},
 		},
 	}
 }
@@ -120,6 +146,28 @@ type emulatorResourceModel struct {
 	Type         types.String `tfsdk:"type"`
 	SystemLabels types.Map    `tfsdk:"system_labels"`
 	Labels       types.Map    `tfsdk:"labels"`
+	LabelsAll    types.Map    `tfsdk:"labels_all"`
+}
+
+// emulatorIdentityModel is the identity of the resource, and of the devices
+// listed by the dt_device list resource.
+type emulatorIdentityModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	DeviceID  types.String `tfsdk:"device_id"`
+}
+
+func (m *emulatorIdentityModel) name() string {
+	return dtapi.DeviceName{ProjectID: m.ProjectID.ValueString(), DeviceID: m.DeviceID.ValueString()}.String()
+}
+
+func (m *emulatorIdentityModel) fromName(name string) error {
+	deviceName, err := dtapi.ParseDeviceName(name)
+	if err != nil {
+		return err
+	}
+	m.ProjectID = types.StringValue(deviceName.ProjectID)
+	m.DeviceID = types.StringValue(deviceName.DeviceID)
+	return nil
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -132,7 +180,7 @@ func (r *emulatorResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	toBeCreated, diags := stateToEmulator(ctx, plan)
+	toBeCreated, diags := stateToEmulator(ctx, plan, r.client.DefaultLabels)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
@@ -145,7 +193,7 @@ func (r *emulatorResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	state, diags := emulatorToState(ctx, created)
+	state, diags := emulatorToState(ctx, created, r.client.DefaultLabels, plan.Labels)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
@@ -157,6 +205,7 @@ func (r *emulatorResource) Create(ctx context.Context, req resource.CreateReques
 	if diags.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &emulatorIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -176,7 +225,7 @@ func (r *emulatorResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	state, diags = emulatorToState(ctx, emulator)
+	state, diags = emulatorToState(ctx, emulator, r.client.DefaultLabels, state.Labels)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
@@ -188,6 +237,7 @@ func (r *emulatorResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if diags.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &emulatorIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Update updates the resource and refreshes the Terraform state.
@@ -208,7 +258,7 @@ func (r *emulatorResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	toBeUpdated, diags := stateToEmulator(ctx, plan)
+	toBeUpdated, diags := stateToEmulator(ctx, plan, r.client.DefaultLabels)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
@@ -221,7 +271,7 @@ func (r *emulatorResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	state, diags = emulatorToState(ctx, updated)
+	state, diags = emulatorToState(ctx, updated, r.client.DefaultLabels, plan.Labels)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
@@ -233,6 +283,7 @@ func (r *emulatorResource) Update(ctx context.Context, req resource.UpdateReques
 	if diags.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &emulatorIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -253,6 +304,48 @@ func (r *emulatorResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+// ValidateConfig checks the display name and labels against the provider policy.
+func (r *emulatorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if r.client == nil || r.client.Policy == nil {
+		return
+	}
+	validateDisplayNamePolicy(ctx, r.client.Policy, req.Config, &resp.Diagnostics)
+	validateLabelsPolicy(ctx, r.client.Policy, req.Config, path.Root("labels"), &resp.Diagnostics)
+}
+
+// ModifyPlan fills in the provider's default project when project_id is not
+// set, and plans labels_all from the labels and the provider's default labels.
+// Any planned change fails when the provider is read only.
+func (r *emulatorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkReadOnly(ctx, r.client, "dt_emulator", nil, req, resp)
+
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("project_id"), "default_project", r.client.DefaultProject, false)
+
+	var labels types.Map
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if labels.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+	labelsMap := make(map[string]string)
+	resp.Diagnostics.Append(labels.ElementsAs(ctx, &labelsMap, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	labelsAll, diags := types.MapValueFrom(ctx, types.StringType, mergeLabels(r.client.DefaultLabels, labelsMap))
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), labelsAll)...)
+}
+
 func (r *emulatorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
 	// sets that data after it calls the ConfigureProvider RPC.
@@ -260,7 +353,7 @@ func (r *emulatorResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*dt.Client)
+	client, ok := req.ProviderData.(*providerClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -273,39 +366,49 @@ func (r *emulatorResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
-func stateToEmulator(ctx context.Context, state emulatorResourceModel) (dt.Emulator, diag.Diagnostics) {
+func stateToEmulator(ctx context.Context, state emulatorResourceModel, defaultLabels map[string]string) (dtapi.Emulator, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	labelsMap := make(map[string]string)
-	d := state.Labels.ElementsAs(ctx, &labelsMap, false)
+	configuredLabels := make(map[string]string)
+	d := state.Labels.ElementsAs(ctx, &configuredLabels, false)
 	diags.Append(d...)
 	if d.HasError() {
-		return dt.Emulator{}, diags
+		return dtapi.Emulator{}, diags
 	}
+	labelsMap := mergeLabels(defaultLabels, configuredLabels)
 
 	// add the system labels
 	labelsMap["name"] = state.DisplayName.ValueString()
 	labelsMap["virtual-sensor"] = ""
 
-	return dt.Emulator{
+	return dtapi.Emulator{
 		Name:   state.Name.ValueString(),
 		Type:   state.Type.ValueString(),
 		Labels: labelsMap,
 	}, diags
 }
 
-func emulatorToState(ctx context.Context, emulator dt.Emulator) (emulatorResourceModel, diag.Diagnostics) {
+// emulatorToState converts the emulator to its Terraform state. Labels that
+// only come from defaultLabels are left out of labels, unless they are set in
+// configuredLabels, but are always included in labels_all.
+func emulatorToState(ctx context.Context, emulator dtapi.Emulator, defaultLabels map[string]string, configuredLabels types.Map) (emulatorResourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	var labels = make(map[string]string)
+	var labelsAll = make(map[string]string)
 	var systemLabels = make(map[string]string)
 	for key, value := range emulator.Labels {
 		if key == "name" || key == "virtual-sensor" {
 			systemLabels[key] = value
 		} else {
-			labels[key] = value
+			labelsAll[key] = value
 		}
 	}
+
+	configured := make(map[string]string)
+	if !configuredLabels.IsNull() && !configuredLabels.IsUnknown() {
+		diags.Append(configuredLabels.ElementsAs(ctx, &configured, false)...)
+	}
+	labels := resourceLabels(labelsAll, defaultLabels, configured)
 	displayName := systemLabels["name"]
 	if displayName == "" {
 		displayName = emulator.DeviceID()
@@ -314,6 +417,9 @@ func emulatorToState(ctx context.Context, emulator dt.Emulator) (emulatorResourc
 	labelsMap, d := basetypes.NewMapValueFrom(ctx, types.StringType, labels)
 	diags.Append(d...)
 
+	labelsAllMap, d := basetypes.NewMapValueFrom(ctx, types.StringType, labelsAll)
+	diags.Append(d...)
+
 	systemLabelsMap, d := basetypes.NewMapValueFrom(ctx, types.StringType, systemLabels)
 	diags.Append(d...)
 
@@ -324,5 +430,6 @@ func emulatorToState(ctx context.Context, emulator dt.Emulator) (emulatorResourc
 		ProjectID:    types.StringValue(emulator.ProjectID()),
 		SystemLabels: systemLabelsMap,
 		Labels:       labelsMap,
+		LabelsAll:    labelsAllMap,
 	}, diags
 }
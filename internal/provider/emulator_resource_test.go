@@ -3,8 +3,17 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -41,7 +50,7 @@ func TestAccEmulatorResource(t *testing.T) {
 				Config: providerConfig +
 					readTestFile(t, "../../testdata/emulator/with_labels.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_emulator.test", "display_name", "Emulator with custom labels"),
+					resource.TestCheckResourceAttr("dt_emulator.test", "display_name", "Acceptance Test emulator with custom labels"),
 					resource.TestCheckResourceAttr("dt_emulator.test", "type", "temperature"),
 					resource.TestCheckResourceAttr("dt_emulator.test", "labels.%", "2"),
 					resource.TestCheckResourceAttr("dt_emulator.test", "labels.foo", "bar"),
@@ -67,3 +76,118 @@ func TestAccEmulatorResource(t *testing.T) {
 		},
 	})
 }
+
+func TestEmulatorList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		case "/v2/projects/p1/devices":
+			fmt.Fprint(w, `{"devices":[
+				{"name":"projects/p1/devices/d1","type":"temperature","labels":{"name":"Fridge","room":"kitchen"}},
+				{"name":"projects/p1/devices/d2","type":"temperature","labels":{"name":"Freezer","room":"kitchen"}},
+				{"name":"projects/p1/devices/d3","type":"touch","labels":{"name":"Button","room":"hall"}}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newProviderClient(providerSettings{
+		URL:            server.URL,
+		EmulatorURL:    server.URL,
+		KeyID:          "key",
+		KeySecret:      "secret",
+		Email:          "service-account@example.com",
+		DefaultProject: "p1",
+	}, server.URL+"/oauth2/token", "test")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	emulator := &emulatorResource{client: client}
+
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	emulator.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	var identitySchemaResp fwresource.IdentitySchemaResponse
+	emulator.IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, &identitySchemaResp)
+	var listSchemaResp list.ListResourceSchemaResponse
+	emulator.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &listSchemaResp)
+
+	labelsType := tftypes.Map{ElementType: tftypes.String}
+	tests := map[string]struct {
+		displayName tftypes.Value
+		labels      tftypes.Value
+		limit       int64
+		want        []string
+	}{
+		"all": {
+			displayName: tftypes.NewValue(tftypes.String, nil),
+			labels:      tftypes.NewValue(labelsType, nil),
+			want:        []string{"d1", "d2", "d3"},
+		},
+		"labels": {
+			displayName: tftypes.NewValue(tftypes.String, nil),
+			labels:      tftypes.NewValue(labelsType, map[string]tftypes.Value{"room": tftypes.NewValue(tftypes.String, "kitchen")}),
+			want:        []string{"d1", "d2"},
+		},
+		"display name and labels": {
+			displayName: tftypes.NewValue(tftypes.String, "Freezer"),
+			labels:      tftypes.NewValue(labelsType, map[string]tftypes.Value{"room": tftypes.NewValue(tftypes.String, "kitchen")}),
+			want:        []string{"d2"},
+		},
+		"limit": {
+			displayName: tftypes.NewValue(tftypes.String, nil),
+			labels:      tftypes.NewValue(labelsType, nil),
+			limit:       1,
+			want:        []string{"d1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := list.ListRequest{
+				Config: tfsdk.Config{
+					Schema: listSchemaResp.Schema,
+					Raw: tftypes.NewValue(listSchemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+						"project_id":   tftypes.NewValue(tftypes.String, nil),
+						"display_name": tt.displayName,
+						"labels":       tt.labels,
+					}),
+				},
+				IncludeResource:        true,
+				Limit:                  tt.limit,
+				ResourceSchema:         schemaResp.Schema,
+				ResourceIdentitySchema: identitySchemaResp.IdentitySchema,
+			}
+			var stream list.ListResultsStream
+			emulator.List(ctx, req, &stream)
+
+			var got []string
+			for result := range stream.Results {
+				if result.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+				}
+				var identity emulatorIdentityModel
+				if diags := result.Identity.Get(ctx, &identity); diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				var state emulatorResourceModel
+				if diags := result.Resource.Get(ctx, &state); diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				if identity.ProjectID.ValueString() != "p1" || state.DisplayName.ValueString() != result.DisplayName {
+					t.Errorf("unexpected result %q: %+v", result.DisplayName, identity)
+				}
+				got = append(got, identity.DeviceID.ValueString())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction runs the provider function with string arguments and returns
+// the result, or the function error.
+func runFunction(t *testing.T, fn function.Function, result attr.Value, args ...string) (attr.Value, *function.FuncError) {
+	t.Helper()
+	arguments := make([]attr.Value, 0, len(args))
+	for _, arg := range args {
+		arguments = append(arguments, types.StringValue(arg))
+	}
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	fn.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestParseResourceNameFunction(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name    string
+		want    map[string]attr.Value
+		wantErr bool
+	}{
+		"device": {
+			name: "projects/p1/devices/d1",
+			want: map[string]attr.Value{
+				"collection":      types.StringValue("devices"),
+				"id":              types.StringValue("d1"),
+				"parent":          types.StringValue("projects/p1"),
+				"project_id":      types.StringValue("p1"),
+				"organization_id": types.StringNull(),
+			},
+		},
+		"organization": {
+			name: "organizations/o1",
+			want: map[string]attr.Value{
+				"collection":      types.StringValue("organizations"),
+				"id":              types.StringValue("o1"),
+				"parent":          types.StringNull(),
+				"project_id":      types.StringNull(),
+				"organization_id": types.StringValue("o1"),
+			},
+		},
+		"invalid": {name: "projects/p1/devices", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := runFunction(t, NewParseResourceNameFunction(), types.ObjectUnknown(resourceNameAttributeTypes), tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := types.ObjectValueMust(resourceNameAttributeTypes, tt.want)
+			if !got.Equal(want) {
+				t.Errorf("expected %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestResourceNameFunctions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fn      function.Function
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		"project_id of a project":         {fn: NewProjectIDFunction(), args: []string{"projects/p1"}, want: "p1"},
+		"project_id of a device":          {fn: NewProjectIDFunction(), args: []string{"projects/p1/devices/d1"}, want: "p1"},
+		"project_id of an organization":   {fn: NewProjectIDFunction(), args: []string{"organizations/o1"}, wantErr: true},
+		"project_id of an invalid name":   {fn: NewProjectIDFunction(), args: []string{"p1"}, wantErr: true},
+		"device_name":                     {fn: NewDeviceNameFunction(), args: []string{"p1", "d1"}, want: "projects/p1/devices/d1"},
+		"device_name with a project name": {fn: NewDeviceNameFunction(), args: []string{"projects/p1", "d1"}, wantErr: true},
+		"device_name with an empty id":    {fn: NewDeviceNameFunction(), args: []string{"p1", ""}, wantErr: true},
+		"rule_name":                       {fn: NewRuleNameFunction(), args: []string{"p1", "r1"}, want: "projects/p1/rules/r1"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := runFunction(t, tt.fn, types.StringUnknown(), tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && !got.Equal(types.StringValue(tt.want)) {
+				t.Errorf("expected %q, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestScheduleFunction(t *testing.T) {
+	t.Parallel()
+
+	timeOfDay := func(hour int32) attr.Value {
+		return types.ObjectValueMust(timeOfDayAttributeTypes, map[string]attr.Value{
+			"hour":   types.Int32Value(hour),
+			"minute": types.Int32Value(0),
+		})
+	}
+	slotType := scheduleAttributeTypes["slots"].(types.ListType).ElemType.(types.ObjectType)
+	timeRangeType := slotType.AttrTypes["time_range"].(types.ListType).ElemType.(types.ObjectType)
+
+	got, err := runFunction(t, NewScheduleFunction(), types.ObjectUnknown(scheduleAttributeTypes), "Sat 10:00-14:00", "Europe/Oslo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := types.ObjectValueMust(scheduleAttributeTypes, map[string]attr.Value{
+		"timezone": types.StringValue("Europe/Oslo"),
+		"inverse":  types.BoolValue(false),
+		"slots": types.ListValueMust(slotType, []attr.Value{
+			types.ObjectValueMust(slotType.AttrTypes, map[string]attr.Value{
+				"day_of_week": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Saturday")}),
+				"time_range": types.ListValueMust(timeRangeType, []attr.Value{
+					types.ObjectValueMust(timeRangeType.AttrTypes, map[string]attr.Value{
+						"start": timeOfDay(10),
+						"end":   timeOfDay(14),
+					}),
+				}),
+			}),
+		}),
+	})
+	if !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	// errors point at the offending argument
+	for argument, args := range map[int64][]string{
+		0: {"Mon-Fri 17:00-08:00", "Europe/Oslo"},
+		1: {"Mon-Fri 08:00-17:00", "Europe/Bergen"},
+	} {
+		if _, err := runFunction(t, NewScheduleFunction(), types.ObjectUnknown(scheduleAttributeTypes), args...); err == nil || err.FunctionArgument == nil || *err.FunctionArgument != argument {
+			t.Errorf("expected an error for argument %d, got %v", argument, err)
+		}
+	}
+}
+
+func TestConvertTemperatureFunction(t *testing.T) {
+	t.Parallel()
+
+	resp := &function.RunResponse{Result: function.NewResultData(types.Float64Unknown())}
+	args := function.NewArgumentsData([]attr.Value{types.Float64Value(41), types.StringValue("F"), types.StringValue("C")})
+	NewConvertTemperatureFunction().Run(context.Background(), function.RunRequest{Arguments: args}, resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	if got := resp.Result.Value(); !got.Equal(types.Float64Value(5)) {
+		t.Errorf("expected 5, got %s", got)
+	}
+
+	resp = &function.RunResponse{Result: function.NewResultData(types.Float64Unknown())}
+	args = function.NewArgumentsData([]attr.Value{types.Float64Value(41), types.StringValue("F"), types.StringValue("R")})
+	NewConvertTemperatureFunction().Run(context.Background(), function.RunRequest{Arguments: args}, resp)
+	if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 2 {
+		t.Errorf("expected an error for argument 2, got %v", resp.Error)
+	}
+}
+
+func TestTemperatureRangeFunction(t *testing.T) {
+	t.Parallel()
+
+	got, err := runFunction(t, NewTemperatureRangeFunction(), types.ObjectUnknown(rangeAttributeTypes), "between 35F and 41F")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := types.ObjectValueMust(rangeAttributeTypes, map[string]attr.Value{
+		"lower":  types.Float64Value(1.67),
+		"upper":  types.Float64Value(5),
+		"type":   types.StringValue("WITHIN"),
+		"filter": types.ObjectNull(rangeAttributeTypes["filter"].(types.ObjectType).AttrTypes),
+	})
+	if !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if _, err := runFunction(t, NewTemperatureRangeFunction(), types.ObjectUnknown(rangeAttributeTypes), "between 41F and 35F"); err == nil {
+		t.Error("expected an error for a range with the bounds swapped")
+	}
+}
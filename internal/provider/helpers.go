@@ -4,13 +4,26 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"iter"
 	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/disruptive-technologies/terraform-provider-dt/internal/policy"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // flattenStringList converts a list of strings to a list of string values.
@@ -55,6 +68,15 @@ func expandStringList(ctx context.Context, listValue basetypes.ListValue) ([]str
 	return result, diags
 }
 
+// writeOnlyOr returns the value of the write-only attribute when it is set, and
+// otherwise the value of the attribute it replaces.
+func writeOnlyOr(writeOnly, value types.String) string {
+	if !writeOnly.IsNull() && !writeOnly.IsUnknown() {
+		return writeOnly.ValueString()
+	}
+	return value.ValueString()
+}
+
 func expandStringSet(ctx context.Context, setValue basetypes.SetValue) ([]string, diag.Diagnostics) {
 	if setValue.IsNull() {
 		return nil, nil
@@ -77,3 +99,413 @@ var durationValidator = stringvalidator.RegexMatches(
 	regexp.MustCompile(`^(\d+)([s])$`),
 	"Duration must be in the format of <number><unit>, where unit is 's' (seconds).",
 )
+
+// planProviderDefault sets the string attribute at attributePath in the plan to
+// the provider level default when it is not set in the configuration, so the
+// resolved value shows up in the plan. providerAttribute names the provider
+// attribute holding the default, for the error when neither is set. When
+// requiresReplace is set, a defaulted value that differs from the state
+// replaces the resource, like an explicit change would.
+func planProviderDefault(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attributePath path.Path, providerAttribute string, value string, requiresReplace bool) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configValue types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attributePath, &configValue)...)
+	if resp.Diagnostics.HasError() || !configValue.IsNull() {
+		return
+	}
+
+	if value == "" {
+		resp.Diagnostics.AddAttributeError(
+			attributePath,
+			fmt.Sprintf("Missing %s", attributePath),
+			fmt.Sprintf("The %s attribute must be set, or the provider must be configured with `%s`.", attributePath, providerAttribute),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attributePath, types.StringValue(value))...)
+
+	if requiresReplace && !req.State.Raw.IsNull() {
+		var stateValue types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, attributePath, &stateValue)...)
+		if stateValue.ValueString() != value {
+			resp.RequiresReplace.Append(attributePath)
+		}
+	}
+}
+
+// mergeLabels returns the provider default labels merged with the labels set
+// on the resource. Labels set on the resource win.
+func mergeLabels(defaults map[string]string, labels map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(labels))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+	return merged
+}
+
+// resourceLabels returns the labels that belong in the resource's own labels
+// attribute: all labels, except those that only come from the provider
+// default labels. Labels in configured are always kept, so a label that is
+// set on the resource with the same value as the default doesn't show a diff.
+func resourceLabels(all map[string]string, defaults map[string]string, configured map[string]string) map[string]string {
+	labels := make(map[string]string, len(all))
+	for key, value := range all {
+		if _, ok := configured[key]; !ok {
+			if defaultValue, ok := defaults[key]; ok && defaultValue == value {
+				continue
+			}
+		}
+		labels[key] = value
+	}
+	return labels
+}
+
+// checkReadOnly fails the plan when the provider is read only and the plan
+// creates, updates, replaces or deletes the resource. replaceAttributes are
+// the attributes that require replacement in the schema. It must run after the
+// rest of ModifyPlan, so changes planned there are taken into account.
+func checkReadOnly(ctx context.Context, client *providerClient, typeName string, replaceAttributes path.Paths, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || !client.ReadOnly() {
+		return
+	}
+
+	var action string
+	switch {
+	case req.State.Raw.IsNull():
+		action = "created"
+	case req.Plan.Raw.IsNull():
+		action = "deleted"
+	case replacementPlanned(ctx, replaceAttributes, req, resp):
+		action = "replaced"
+	case !resp.Plan.Raw.Equal(req.State.Raw):
+		action = "updated"
+	default:
+		return
+	}
+	tflog.Debug(ctx, "refusing planned change in read only mode", map[string]interface{}{"resource": typeName, "action": action})
+	resp.Diagnostics.AddError(
+		"Provider is read only",
+		fmt.Sprintf("The %s resource would be %s, but the provider is configured with `read_only` (DT_READ_ONLY). "+
+			"No changes can be made in read only mode.", typeName, action),
+	)
+}
+
+// deletionProtectionAttribute is the deletion_protection attribute of the
+// resources that can be protected from being destroyed. It is only stored in
+// the state and never sent to DT.
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		Description: fmt.Sprintf("Whether Terraform is prevented from destroying or replacing the %[1]s. "+
+			"The setting is only stored in the Terraform state, so set it to `false` and apply before destroying the %[1]s.", kind),
+	}
+}
+
+// deletionProtectionFromPrior returns the deletion protection of the prior
+// plan or state, which isn't known to the API. Imported resources are not
+// protected until the configuration says so.
+func deletionProtectionFromPrior(prior types.Bool) types.Bool {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return prior
+}
+
+// checkDeletionProtection fails the plan when it destroys or replaces a
+// resource with deletion_protection set in the state. replaceAttributes are
+// the attributes that require replacement in the schema. Like checkReadOnly,
+// it must run after the rest of ModifyPlan.
+func checkDeletionProtection(ctx context.Context, typeName string, replaceAttributes path.Paths, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var action string
+	switch {
+	case req.Plan.Raw.IsNull():
+		action = "destroyed"
+	case replacementPlanned(ctx, replaceAttributes, req, resp):
+		action = "replaced"
+	default:
+		return
+	}
+	var protected types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if protected.ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, typeName, action)
+	}
+}
+
+// replacementPlanned reports whether the plan replaces the resource. The
+// framework calls ModifyPlan before it adds the replacements required by
+// attribute plan modifiers, so resp.RequiresReplace only holds those added in
+// ModifyPlan. The replaceAttributes, those with a RequiresReplace or
+// RequiresReplaceIfConfigured plan modifier, are compared between the plan
+// and the state instead. Attributes that aren't configured are skipped, like
+// RequiresReplaceIfConfigured does.
+func replacementPlanned(ctx context.Context, replaceAttributes path.Paths, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	if req.State.Raw.IsNull() || resp.Plan.Raw.IsNull() {
+		return false
+	}
+	if len(resp.RequiresReplace) > 0 {
+		return true
+	}
+	for _, attributePath := range replaceAttributes {
+		var configValue, planValue, stateValue attr.Value
+		diags := req.Config.GetAttribute(ctx, attributePath, &configValue)
+		diags.Append(resp.Plan.GetAttribute(ctx, attributePath, &planValue)...)
+		diags.Append(req.State.GetAttribute(ctx, attributePath, &stateValue)...)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			continue
+		}
+		if !configValue.IsNull() && !planValue.Equal(stateValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// addDeletionProtectionError reports that a protected resource can't be
+// destroyed or replaced.
+func addDeletionProtectionError(diags *diag.Diagnostics, typeName string, action string) {
+	diags.AddError(
+		"Deletion protection is enabled",
+		fmt.Sprintf("The %s resource would be %s, but it has `deletion_protection` set. "+
+			"Set `deletion_protection = false` and apply before destroying or replacing it.", typeName, action),
+	)
+}
+
+// addPolicyViolation reports a policy violation on the attribute, as an error
+// or a warning depending on the severity of the policy.
+func addPolicyViolation(diags *diag.Diagnostics, policy *policy.Policy, attributePath path.Path, err error) {
+	detail := fmt.Sprintf("The configuration violates the provider policy: %s.", err)
+	if policy.IsWarning() {
+		diags.AddAttributeWarning(attributePath, "Policy violation", detail)
+		return
+	}
+	diags.AddAttributeError(attributePath, "Policy violation", detail)
+}
+
+// validateDisplayNamePolicy checks the display_name attribute in the
+// configuration against the provider policy.
+func validateDisplayNamePolicy(ctx context.Context, policy *policy.Policy, config tfsdk.Config, diags *diag.Diagnostics) {
+	var displayName types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("display_name"), &displayName)...)
+	if displayName.IsNull() || displayName.IsUnknown() {
+		return
+	}
+	if err := policy.CheckDisplayName(displayName.ValueString()); err != nil {
+		addPolicyViolation(diags, policy, path.Root("display_name"), err)
+	}
+}
+
+// validateLabelsPolicy checks a map of labels in the configuration against the
+// provider policy. An empty value only checks the key, for label filters that
+// match on the key alone.
+func validateLabelsPolicy(ctx context.Context, policy *policy.Policy, config tfsdk.Config, attributePath path.Path, diags *diag.Diagnostics) {
+	var labels types.Map
+	diags.Append(config.GetAttribute(ctx, attributePath, &labels)...)
+	for key, element := range labels.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		var err error
+		if value.ValueString() == "" {
+			err = policy.CheckLabelKey(key)
+		} else {
+			err = policy.CheckLabel(key, value.ValueString())
+		}
+		if err != nil {
+			addPolicyViolation(diags, policy, attributePath.AtMapKey(key), err)
+		}
+	}
+}
+
+// validateLabelKeysPolicy checks a list of label keys in the configuration
+// against the provider policy.
+func validateLabelKeysPolicy(ctx context.Context, policy *policy.Policy, config tfsdk.Config, attributePath path.Path, diags *diag.Diagnostics) {
+	var keys types.List
+	diags.Append(config.GetAttribute(ctx, attributePath, &keys)...)
+	for i, element := range keys.Elements() {
+		key, ok := element.(types.String)
+		if !ok || key.IsNull() || key.IsUnknown() {
+			continue
+		}
+		if err := policy.CheckLabelKey(key.ValueString()); err != nil {
+			addPolicyViolation(diags, policy, attributePath.AtListIndex(i), err)
+		}
+	}
+}
+
+// resourceIdentity is the identity model of a resource, which maps to and from
+// the resource name.
+type resourceIdentity interface {
+	// name returns the resource name the identity refers to.
+	name() string
+	// fromName sets the identity from the resource name.
+	fromName(name string) error
+}
+
+// setIdentity sets the identity of the resource from its resource name.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, model resourceIdentity, name types.String, diags *diag.Diagnostics) {
+	if identity == nil || name.IsNull() || name.IsUnknown() {
+		return
+	}
+	if err := model.fromName(name.ValueString()); err != nil {
+		diags.AddError("failed to set resource identity", err.Error())
+		return
+	}
+	diags.Append(identity.Set(ctx, model)...)
+}
+
+// importIDResolver resolves an import ID that isn't a resource name, such as
+// {project_id}/{display_name}, to the resource name.
+type importIDResolver func(ctx context.Context, id string) (string, error)
+
+// importStateByNameOrIdentity imports the resource by the resource name given as
+// import ID, or by the identity given in an import block. Other import IDs are
+// resolved to a resource name with resolve, if set.
+func importStateByNameOrIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, model resourceIdentity, resolve importIDResolver) {
+	if req.ID != "" {
+		name := req.ID
+		if _, err := dtapi.ParseName(req.ID); err != nil && resolve != nil {
+			if name, err = resolve(ctx, req.ID); err != nil {
+				resp.Diagnostics.AddError(
+					"Error importing resource",
+					fmt.Sprintf("Could not resolve import ID %q: %s", req.ID, err),
+				)
+				return
+			}
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+		return
+	}
+
+	resp.Diagnostics.Append(req.Identity.Get(ctx, model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), model.name())...)
+}
+
+// splitImportID splits an import ID into the named parts, separated by slashes.
+// The last part takes the rest of the ID, so display names may contain slashes.
+func splitImportID(id string, parts ...string) ([]string, error) {
+	values := strings.SplitN(id, "/", len(parts))
+	if len(values) != len(parts) || slices.Contains(values, "") {
+		return nil, fmt.Errorf("expected a resource name or {%s}", strings.Join(parts, "}/{"))
+	}
+	return values, nil
+}
+
+// importCandidate is a resource that an import ID may refer to.
+type importCandidate struct {
+	// name is the resource name of the candidate.
+	name string
+	// key is the value the import ID is matched against, such as the display name.
+	key string
+}
+
+// matchImportID returns the name of the only candidate whose key is key. It
+// fails if no candidate matches, or if several do, listing the matches so that
+// one can be imported by resource name instead.
+func matchImportID(kind, key string, candidates []importCandidate) (string, error) {
+	var matches []string
+	for _, candidate := range candidates {
+		if candidate.key == key && !slices.Contains(matches, candidate.name) {
+			matches = append(matches, candidate.name)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s matches %q", kind, key)
+	case 1:
+		return matches[0], nil
+	}
+	slices.Sort(matches)
+	return "", fmt.Errorf("%d %ss match %q, import one of them by resource name instead:\n  - %s", len(matches), kind, key, strings.Join(matches, "\n  - "))
+}
+
+// unconfiguredClientDiagnostics returns the error of listing resources before
+// the provider is configured.
+func unconfiguredClientDiagnostics() diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.AddError(
+		"Unconfigured provider",
+		"Resources can't be listed before the provider is configured. Please report this issue to the provider developers.",
+	)
+	return diags
+}
+
+// listConfigDefault returns the value of the string attribute at attributePath in
+// a list config, or the provider level default when it is not set.
+func listConfigDefault(value types.String, attributePath path.Path, providerAttribute string, defaultValue string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !value.IsNull() {
+		return value.ValueString(), diags
+	}
+	if defaultValue == "" {
+		diags.AddAttributeError(
+			attributePath,
+			fmt.Sprintf("Missing %s", attributePath),
+			fmt.Sprintf("The %s attribute must be set, or the provider must be configured with `%s`.", attributePath, providerAttribute),
+		)
+	}
+	return defaultValue, diags
+}
+
+// matchesListFilter returns true if the display name equals the display name
+// filter, when set, and labels include every label of the labels filter.
+func matchesListFilter(displayNameFilter types.String, labelsFilter map[string]string, displayName string, labels map[string]string) bool {
+	if !displayNameFilter.IsNull() && displayNameFilter.ValueString() != displayName {
+		return false
+	}
+	for key, value := range labelsFilter {
+		if labelValue, ok := labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// newListResult returns the list result of a resource with its identity and, if
+// the request includes resources, its state.
+func newListResult(ctx context.Context, req list.ListRequest, displayName string, name types.String, identity resourceIdentity, state any, diags diag.Diagnostics) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+	result.Diagnostics.Append(diags...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+	setIdentity(ctx, result.Identity, identity, name, &result.Diagnostics)
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.Set(ctx, state)...)
+	}
+	return result
+}
+
+// listResults streams a list result for each item, up to the limit of the request.
+func listResults[T any](req list.ListRequest, items []T, result func(item T) list.ListResult) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		for i, item := range items {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+			if !push(result(item)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,714 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/internal/policy"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPlanProviderDefault(t *testing.T) {
+	t.Parallel()
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project": schema.StringAttribute{Optional: true, Computed: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"project": tftypes.String}}
+	object := func(project interface{}) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{"project": tftypes.NewValue(tftypes.String, project)})
+	}
+
+	tests := map[string]struct {
+		config          interface{}
+		state           tftypes.Value
+		value           string
+		requiresReplace bool
+		want            types.String
+		wantReplace     bool
+		wantErr         bool
+	}{
+		"configured value is kept": {
+			config: "projects/configured",
+			state:  tftypes.NewValue(objectType, nil),
+			value:  "projects/default",
+			want:   types.StringValue("projects/configured"),
+		},
+		"default fills in the plan": {
+			config: nil,
+			state:  tftypes.NewValue(objectType, nil),
+			value:  "projects/default",
+			want:   types.StringValue("projects/default"),
+		},
+		"missing value and default": {
+			config:  nil,
+			state:   tftypes.NewValue(objectType, nil),
+			wantErr: true,
+		},
+		"changed default replaces the resource": {
+			config:          nil,
+			state:           object("projects/old"),
+			value:           "projects/default",
+			requiresReplace: true,
+			want:            types.StringValue("projects/default"),
+			wantReplace:     true,
+		},
+		"unchanged default": {
+			config:          nil,
+			state:           object("projects/default"),
+			value:           "projects/default",
+			requiresReplace: true,
+			want:            types.StringValue("projects/default"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			planned := object(tt.config)
+			if tt.config == nil {
+				planned = object(tftypes.UnknownValue)
+			}
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: testSchema, Raw: object(tt.config)},
+				Plan:   tfsdk.Plan{Schema: testSchema, Raw: planned},
+				State:  tfsdk.State{Schema: testSchema, Raw: tt.state},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			planProviderDefault(ctx, req, resp, path.Root("project"), "default_project", tt.value, tt.requiresReplace)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			var got types.String
+			resp.Plan.GetAttribute(ctx, path.Root("project"), &got)
+			if !got.Equal(tt.want) {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+			if replace := len(resp.RequiresReplace) > 0; replace != tt.wantReplace {
+				t.Errorf("expected requires replace %t, got %v", tt.wantReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestResourceLabels(t *testing.T) {
+	t.Parallel()
+
+	defaults := map[string]string{"managed-by": "terraform", "cost-centre": "42"}
+
+	tests := map[string]struct {
+		all        map[string]string
+		configured map[string]string
+		want       map[string]string
+	}{
+		"defaults are left out": {
+			all:  map[string]string{"managed-by": "terraform", "cost-centre": "42", "room": "101"},
+			want: map[string]string{"room": "101"},
+		},
+		"overridden default is kept": {
+			all:  map[string]string{"managed-by": "terraform", "cost-centre": "7"},
+			want: map[string]string{"cost-centre": "7"},
+		},
+		"configured label equal to the default is kept": {
+			all:        map[string]string{"managed-by": "terraform", "cost-centre": "42"},
+			configured: map[string]string{"cost-centre": "42"},
+			want:       map[string]string{"cost-centre": "42"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := resourceLabels(tt.all, defaults, tt.configured)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			// merging the resource labels with the defaults gives back all labels
+			if merged := mergeLabels(defaults, got); !reflect.DeepEqual(merged, tt.all) {
+				t.Errorf("expected merged labels %v, got %v", tt.all, merged)
+			}
+		})
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	t.Parallel()
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"display_name": schema.StringAttribute{Required: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"display_name": tftypes.String}}
+	object := func(displayName string) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{"display_name": tftypes.NewValue(tftypes.String, displayName)})
+	}
+	null := tftypes.NewValue(objectType, nil)
+
+	tests := map[string]struct {
+		readOnly bool
+		state    tftypes.Value
+		plan     tftypes.Value
+		wantErr  bool
+	}{
+		"create":               {readOnly: true, state: null, plan: object("new"), wantErr: true},
+		"update":               {readOnly: true, state: object("old"), plan: object("new"), wantErr: true},
+		"delete":               {readOnly: true, state: object("old"), plan: null, wantErr: true},
+		"no change":            {readOnly: true, state: object("old"), plan: object("old")},
+		"create, not readonly": {readOnly: false, state: null, plan: object("new")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := resource.ModifyPlanRequest{
+				Plan:  tfsdk.Plan{Schema: testSchema, Raw: tt.plan},
+				State: tfsdk.State{Schema: testSchema, Raw: tt.state},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			client, err := dtapi.New(dtapi.WithReadOnly(tt.readOnly))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			checkReadOnly(context.Background(), &providerClient{Client: client}, "dt_test", nil, req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCheckReadOnlyReplace(t *testing.T) {
+	t.Parallel()
+
+	providerConfig := map[string]tftypes.Value{
+		"url":                         tftypes.NewValue(tftypes.String, "https://api.example.com"),
+		"token_endpoint":              tftypes.NewValue(tftypes.String, "https://identity.example.com/oauth2/token"),
+		"key_id":                      tftypes.NewValue(tftypes.String, "key"),
+		"key_secret":                  tftypes.NewValue(tftypes.String, "secret"),
+		"email":                       tftypes.NewValue(tftypes.String, "service-account@example.com"),
+		"config_file":                 tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "config")),
+		"read_only":                   tftypes.NewValue(tftypes.Bool, true),
+		"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, true),
+	}
+	prior := dataConnectorState(false, nil)
+	config := func(name string, value string) map[string]tftypes.Value {
+		return dataConnectorConfig(false, map[string]tftypes.Value{name: tftypes.NewValue(tftypes.String, value)})
+	}
+
+	tests := map[string]struct {
+		config     map[string]tftypes.Value
+		wantAction string
+	}{
+		// type has a RequiresReplace plan modifier in the schema.
+		"replace": {config: config("type", "AWS_SQS"), wantAction: "replaced"},
+		"update":  {config: config("display_name", "Fridge"), wantAction: "updated"},
+		"destroy": {wantAction: "deleted"},
+		"no-op":   {config: config("display_name", "Freezer")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := planResourceChange(t, "dt_data_connector", providerConfig, prior, tt.config)
+
+			var details []string
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					details = append(details, d.Detail)
+				}
+			}
+			switch {
+			case tt.wantAction == "" && len(details) > 0:
+				t.Errorf("unexpected errors: %v", details)
+			case tt.wantAction != "" && (len(details) != 1 || !strings.Contains(details[0], "would be "+tt.wantAction)):
+				t.Errorf("expected the resource to be %s, got: %v", tt.wantAction, details)
+			}
+		})
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	state := dataConnectorState
+	config := dataConnectorConfig
+	newType := map[string]tftypes.Value{"type": tftypes.NewValue(tftypes.String, "AWS_SQS")}
+
+	tests := map[string]struct {
+		prior       map[string]tftypes.Value
+		config      map[string]tftypes.Value
+		wantErr     bool
+		wantReplace bool
+	}{
+		"destroy protected": {prior: state(true, nil), wantErr: true},
+		"replace protected": {prior: state(true, nil), config: config(true, newType), wantErr: true, wantReplace: true},
+		"replace project protected": {
+			prior:       state(true, nil),
+			config:      config(true, map[string]tftypes.Value{"project": tftypes.NewValue(tftypes.String, "projects/p2")}),
+			wantErr:     true,
+			wantReplace: true,
+		},
+		"replace with unprotect": {prior: state(true, nil), config: config(false, newType), wantErr: true, wantReplace: true},
+		"update protected": {
+			prior:  state(true, nil),
+			config: config(true, map[string]tftypes.Value{"display_name": tftypes.NewValue(tftypes.String, "Fridge")}),
+		},
+		"unprotect":           {prior: state(true, nil), config: config(false, nil)},
+		"destroy unprotected": {prior: state(false, nil)},
+		"replace unprotected": {prior: state(false, nil), config: config(false, newType), wantReplace: true},
+		"create":              {config: config(true, nil)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := planResourceChange(t, "dt_data_connector", nil, tt.prior, tt.config)
+
+			if hasErrorDiagnostic(resp.Diagnostics) != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if !tt.wantErr && (len(resp.RequiresReplace) > 0) != tt.wantReplace {
+				t.Errorf("expected replace %t, got %v", tt.wantReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestReplaceAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resource          resource.Resource
+		replaceAttributes path.Paths
+	}{
+		"dt_data_connector": {resource: NewDataConnectorResource(), replaceAttributes: dataConnectorReplaceAttributes},
+		"dt_project_member_role_bindings": {
+			resource:          NewMemberResource(),
+			replaceAttributes: projectMemberRoleBindingsReplaceAttributes,
+		},
+		"dt_project":           {resource: NewProjectResource()},
+		"dt_emulator":          {resource: NewEmulatorResource()},
+		"dt_notification_rule": {resource: NewNotificationRuleResource()},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			var schemaResp resource.SchemaResponse
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			// RequiresReplace and RequiresReplaceIfConfigured describe
+			// themselves as destroying and recreating the resource.
+			var want []string
+			for attributeName, attribute := range schemaResp.Schema.Attributes {
+				modifiers := reflect.ValueOf(attribute).FieldByName("PlanModifiers")
+				for i := 0; modifiers.IsValid() && i < modifiers.Len(); i++ {
+					describer := modifiers.Index(i).Interface().(interface{ Description(context.Context) string })
+					if strings.Contains(describer.Description(ctx), "destroy and recreate") {
+						want = append(want, attributeName)
+					}
+				}
+			}
+			var got []string
+			for _, attributePath := range tt.replaceAttributes {
+				got = append(got, attributePath.String())
+			}
+			slices.Sort(want)
+			slices.Sort(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("expected replace attributes %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+// dataConnectorState returns the state of an HTTP push data connector, with
+// the attributes replaced.
+func dataConnectorState(protected bool, attributes map[string]tftypes.Value) map[string]tftypes.Value {
+	values := map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "projects/p1/dataconnectors/dc1"),
+		"display_name":        tftypes.NewValue(tftypes.String, "Freezer"),
+		"type":                tftypes.NewValue(tftypes.String, "HTTP_PUSH"),
+		"project":             tftypes.NewValue(tftypes.String, "projects/p1"),
+		"status":              tftypes.NewValue(tftypes.String, "ACTIVE"),
+		"events":              tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{}),
+		"labels":              tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "name")}),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, protected),
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+	return values
+}
+
+// dataConnectorConfig returns the configuration of the data connector of
+// dataConnectorState, with the attributes replaced.
+func dataConnectorConfig(protected bool, attributes map[string]tftypes.Value) map[string]tftypes.Value {
+	values := dataConnectorState(protected, attributes)
+	delete(values, "name")
+	delete(values, "status")
+	return values
+}
+
+// planResourceChange plans a change to the resource through the provider
+// server, so that attribute plan modifiers and ModifyPlan run in the same
+// order as they do in Terraform. The provider is configured with
+// providerConfig unless it is nil. The proposed new state is the prior state
+// with the config applied; a nil prior plans creating the resource and a nil
+// config plans destroying it. Attributes that aren't set are null.
+func planResourceChange(t *testing.T, typeName string, providerConfig, prior, config map[string]tftypes.Value) *tfprotov6.PlanResourceChangeResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	server, schemaResp := newProviderServer(t, providerConfig)
+
+	var proposed map[string]tftypes.Value
+	if config != nil {
+		proposed = make(map[string]tftypes.Value, len(prior)+len(config))
+		for name, value := range prior {
+			proposed[name] = value
+		}
+		for name, value := range config {
+			proposed[name] = value
+		}
+	}
+	objectType := schemaResp.ResourceSchemas[typeName].ValueType()
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       dynamicValue(t, objectType, prior),
+		ProposedNewState: dynamicValue(t, objectType, proposed),
+		Config:           dynamicValue(t, objectType, config),
+	})
+	if err != nil {
+		t.Fatalf("failed to plan resource change: %v", err)
+	}
+	return resp
+}
+
+// newProviderServer returns the protocol server of the provider and its schema.
+// The provider is configured with providerConfig unless it is nil.
+func newProviderServer(t *testing.T, providerConfig map[string]tftypes.Value) (tfprotov6.ProviderServerWithListResource, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("failed to get provider schema: %v", err)
+	}
+	if hasErrorDiagnostic(schemaResp.Diagnostics) {
+		t.Fatalf("failed to get provider schema: %v", schemaResp.Diagnostics)
+	}
+	if providerConfig != nil {
+		configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+			Config: dynamicValue(t, schemaResp.Provider.ValueType(), providerConfig),
+		})
+		if err != nil {
+			t.Fatalf("failed to configure provider: %v", err)
+		}
+		if hasErrorDiagnostic(configureResp.Diagnostics) {
+			t.Fatalf("failed to configure provider: %v", configureResp.Diagnostics)
+		}
+	}
+	return server.(tfprotov6.ProviderServerWithListResource), schemaResp
+}
+
+// testServerProviderConfig returns the provider configuration of a provider
+// using serverURL for the API, emulator and token endpoint, with default
+// organization o1 and default project p1.
+func testServerProviderConfig(t *testing.T, serverURL string) map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"url":                         tftypes.NewValue(tftypes.String, serverURL),
+		"emulator_url":                tftypes.NewValue(tftypes.String, serverURL),
+		"token_endpoint":              tftypes.NewValue(tftypes.String, serverURL+"/oauth2/token"),
+		"key_id":                      tftypes.NewValue(tftypes.String, "key"),
+		"key_secret":                  tftypes.NewValue(tftypes.String, "secret"),
+		"email":                       tftypes.NewValue(tftypes.String, "service-account@example.com"),
+		"config_file":                 tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "config")),
+		"token_cache":                 tftypes.NewValue(tftypes.Bool, false),
+		"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, true),
+		"default_organization":        tftypes.NewValue(tftypes.String, "o1"),
+		"default_project":             tftypes.NewValue(tftypes.String, "p1"),
+	}
+}
+
+// listResource lists the resources of typeName with the list config through
+// the provider server, and returns the display names of the results. A nil
+// config is a config without any filters.
+func listResource(t *testing.T, typeName string, providerConfig, config map[string]tftypes.Value, limit int64) []string {
+	t.Helper()
+
+	if config == nil {
+		config = map[string]tftypes.Value{}
+	}
+	ctx := context.Background()
+	server, schemaResp := newProviderServer(t, providerConfig)
+	stream, err := server.ListResource(ctx, &tfprotov6.ListResourceRequest{
+		TypeName:        typeName,
+		Config:          dynamicValue(t, schemaResp.ListResourceSchemas[typeName].ValueType(), config),
+		IncludeResource: true,
+		Limit:           limit,
+	})
+	if err != nil {
+		t.Fatalf("failed to list resources: %v", err)
+	}
+
+	var displayNames []string
+	for result := range stream.Results {
+		if hasErrorDiagnostic(result.Diagnostics) {
+			t.Fatalf("failed to list resources: %v", diagnosticSummaries(result.Diagnostics))
+		}
+		if result.Identity == nil || result.Resource == nil {
+			t.Fatalf("expected the identity and resource of %q", result.DisplayName)
+		}
+		displayNames = append(displayNames, result.DisplayName)
+	}
+	return displayNames
+}
+
+// dynamicValue returns the object of the values, with every attribute that
+// isn't in values null. Nil values return a null object.
+func dynamicValue(t *testing.T, objectType tftypes.Type, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	value := tftypes.NewValue(objectType, nil)
+	if values != nil {
+		attributes := make(map[string]tftypes.Value)
+		for name, attributeType := range objectType.(tftypes.Object).AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+			if v, ok := values[name]; ok {
+				attributes[name] = v
+			}
+		}
+		value = tftypes.NewValue(objectType, attributes)
+	}
+	dynamicValue, err := tfprotov6.NewDynamicValue(objectType, value)
+	if err != nil {
+		t.Fatalf("failed to create dynamic value: %v", err)
+	}
+	return &dynamicValue
+}
+
+// diagnosticSummaries returns the summaries of the diagnostics.
+func diagnosticSummaries(diagnostics []*tfprotov6.Diagnostic) []string {
+	var summaries []string
+	for _, d := range diagnostics {
+		summaries = append(summaries, d.Summary+": "+d.Detail)
+	}
+	return summaries
+}
+
+// hasErrorDiagnostic reports whether any of the diagnostics is an error.
+func hasErrorDiagnostic(diagnostics []*tfprotov6.Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateLabelsPolicy(t *testing.T) {
+	t.Parallel()
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"device_labels": schema.MapAttribute{Optional: true, ElementType: types.StringType},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"device_labels": tftypes.Map{ElementType: tftypes.String}}}
+	config := tfsdk.Config{Schema: testSchema, Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"device_labels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"room":        tftypes.NewValue(tftypes.String, ""),
+			"cost-centre": tftypes.NewValue(tftypes.String, "marketing"),
+			"owner":       tftypes.NewValue(tftypes.String, "team-a"),
+		}),
+	})}
+
+	for _, severity := range []string{policy.SeverityError, policy.SeverityWarning} {
+		t.Run(severity, func(t *testing.T) {
+			t.Parallel()
+			labelPolicy := &policy.Policy{
+				AllowedLabelKeys:   []string{"cost-centre", "room"},
+				LabelValuePatterns: map[string]*regexp.Regexp{"cost-centre": regexp.MustCompile(`^\d+$`), "room": regexp.MustCompile(`^\d+$`)},
+				Severity:           severity,
+			}
+			var diags diag.Diagnostics
+			validateLabelsPolicy(context.Background(), labelPolicy, config, path.Root("device_labels"), &diags)
+
+			// "owner" is not allowed and "cost-centre" doesn't match, "room" without a value only checks the key
+			got := diags.ErrorsCount()
+			if severity == policy.SeverityWarning {
+				got = diags.WarningsCount()
+			}
+			if got != 2 || len(diags) != 2 {
+				t.Errorf("expected 2 %s diagnostics, got %v", severity, diags)
+			}
+		})
+	}
+}
+
+func TestResourceIdentity(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		identity resourceIdentity
+		name     string
+		wantErr  bool
+	}{
+		"project":                 {identity: &projectIdentityModel{}, name: "projects/p1"},
+		"data connector":          {identity: &dataConnectorIdentityModel{}, name: "projects/p1/dataconnectors/dc1"},
+		"notification rule":       {identity: &notificationRuleIdentityModel{}, name: "projects/p1/rules/r1"},
+		"emulator":                {identity: &emulatorIdentityModel{}, name: "projects/p1/devices/emu1"},
+		"member role binding":     {identity: &memberIdentityModel{}, name: "organizations/o1/roles/project.user/members/m1"},
+		"project in organization": {identity: &projectIdentityModel{}, name: "organizations/o1/projects/p1", wantErr: true},
+		"wrong collection":        {identity: &notificationRuleIdentityModel{}, name: "projects/p1/devices/d1", wantErr: true},
+		"nested resource":         {identity: &emulatorIdentityModel{}, name: "projects/p1/things/t1/devices/d1", wantErr: true},
+		"invalid member":          {identity: &memberIdentityModel{}, name: "organizations/o1/members/m1", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.identity.fromName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && tt.identity.name() != tt.name {
+				t.Errorf("expected %q, got %q", tt.name, tt.identity.name())
+			}
+		})
+	}
+}
+
+func TestSplitImportID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		id      string
+		parts   []string
+		want    []string
+		wantErr bool
+	}{
+		"display name":            {id: "p1/Freezer alarm", parts: []string{"project_id", "display_name"}, want: []string{"p1", "Freezer alarm"}},
+		"display name with slash": {id: "p1/Fridge 1/2", parts: []string{"project_id", "display_name"}, want: []string{"p1", "Fridge 1/2"}},
+		"email and role":          {id: "o1/jane@example.com/project.user", parts: []string{"organization_id", "email", "role"}, want: []string{"o1", "jane@example.com", "project.user"}},
+		"missing part":            {id: "p1", parts: []string{"project_id", "display_name"}, wantErr: true},
+		"empty part":              {id: "p1/", parts: []string{"project_id", "display_name"}, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := splitImportID(tt.id, tt.parts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchImportID(t *testing.T) {
+	t.Parallel()
+
+	candidates := []importCandidate{
+		{name: "projects/p1/rules/r1", key: "Freezer alarm"},
+		{name: "projects/p1/rules/r2", key: "Fridge alarm"},
+		{name: "projects/p1/rules/r3", key: "Fridge alarm"},
+		{name: "projects/p1/rules/r4", key: "Door open"},
+		{name: "projects/p1/rules/r4", key: "Door open"},
+	}
+
+	tests := map[string]struct {
+		key     string
+		want    string
+		wantErr *regexp.Regexp
+	}{
+		"unique":             {key: "Freezer alarm", want: "projects/p1/rules/r1"},
+		"duplicate resource": {key: "Door open", want: "projects/p1/rules/r4"},
+		"no match":           {key: "Humidity", wantErr: regexp.MustCompile(`no notification rule matches "Humidity"`)},
+		"ambiguous":          {key: "Fridge alarm", wantErr: regexp.MustCompile(`(?s)2 notification rules match "Fridge alarm".*projects/p1/rules/r2.*projects/p1/rules/r3`)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := matchImportID("notification rule", tt.key, candidates)
+			if tt.wantErr != nil {
+				if err == nil || !tt.wantErr.MatchString(err.Error()) {
+					t.Fatalf("expected error matching %s, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestListUnconfigured(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, schemaResp := newProviderServer(t, nil)
+	for typeName, listSchema := range schemaResp.ListResourceSchemas {
+		t.Run(typeName, func(t *testing.T) {
+			t.Parallel()
+			stream, err := server.ListResource(ctx, &tfprotov6.ListResourceRequest{
+				TypeName: typeName,
+				Config:   dynamicValue(t, listSchema.ValueType(), map[string]tftypes.Value{}),
+			})
+			if err != nil {
+				t.Fatalf("failed to list resources: %v", err)
+			}
+			var summaries []string
+			for result := range stream.Results {
+				summaries = append(summaries, diagnosticSummaries(result.Diagnostics)...)
+			}
+			if len(summaries) != 1 || !strings.HasPrefix(summaries[0], "Unconfigured provider") {
+				t.Errorf("expected the unconfigured provider error, got %v", summaries)
+			}
+		})
+	}
+}
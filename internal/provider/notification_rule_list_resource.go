@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &notificationRuleResource{}
+	_ list.ListResourceWithConfigure = &notificationRuleResource{}
+)
+
+// NewNotificationRuleListResource is a helper function to simplify the provider implementation.
+func NewNotificationRuleListResource() list.ListResource {
+	return &notificationRuleResource{}
+}
+
+type notificationRuleListModel struct {
+	ProjectID   types.String `tfsdk:"project_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Labels      types.Map    `tfsdk:"labels"`
+}
+
+// ListResourceConfigSchema defines the filters for listing notification rules.
+func (r *notificationRuleResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the notification rules in a project, optionally filtered by display name and device labels.",
+		Attributes: map[string]listschema.Attribute{
+			"project_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the project to list notification rules in. Defaults to the provider's `default_project`.",
+			},
+			"display_name": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list notification rules with this display name.",
+			},
+			"labels": listschema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list notification rules whose `device_labels` include all of these labels.",
+			},
+		},
+	}
+}
+
+// List lists the notification rules in a project.
+func (r *notificationRuleResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	if r.client == nil {
+		resp.Results = list.ListResultsStreamDiagnostics(unconfiguredClientDiagnostics())
+		return
+	}
+
+	var config notificationRuleListModel
+	diags := req.Config.Get(ctx, &config)
+	labels := make(map[string]string)
+	if !config.Labels.IsNull() {
+		diags.Append(config.Labels.ElementsAs(ctx, &labels, false)...)
+	}
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	projectID, diags := listConfigDefault(config.ProjectID, path.Root("project_id"), "default_project", r.client.DefaultProject)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	rules, err := r.client.ListNotificationRules(ctx, projectID)
+	if err != nil {
+		diags.AddError("Error listing notification rules", "Could not list notification rules: "+err.Error())
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var matches []dtapi.NotificationRule
+	for _, rule := range rules {
+		if matchesListFilter(config.DisplayName, labels, rule.DisplayName, rule.DeviceLabels) {
+			matches = append(matches, rule)
+		}
+	}
+
+	resp.Results = listResults(req, matches, func(rule dtapi.NotificationRule) list.ListResult {
+		state, diags := notificationRuleToState(ctx, rule)
+		return newListResult(ctx, req, rule.DisplayName, state.Name, &notificationRuleIdentityModel{}, &state, diags)
+	})
+}
@@ -8,7 +8,8 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/disruptive-technologies/terraform-provider-dt/internal/dt"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
@@ -83,9 +85,13 @@ var (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &notificationRuleResource{}
-	_ resource.ResourceWithConfigure   = &notificationRuleResource{}
-	_ resource.ResourceWithImportState = &notificationRuleResource{}
+	_ resource.Resource                   = &notificationRuleResource{}
+	_ resource.ResourceWithConfigure      = &notificationRuleResource{}
+	_ resource.ResourceWithModifyPlan     = &notificationRuleResource{}
+	_ resource.ResourceWithValidateConfig = &notificationRuleResource{}
+	_ resource.ResourceWithImportState    = &notificationRuleResource{}
+	_ resource.ResourceWithIdentity       = &notificationRuleResource{}
+	_ resource.ResourceWithUpgradeState   = &notificationRuleResource{}
 )
 
 // NewDataConnectorResource is a helper function to simplify the provider implementation.
@@ -95,7 +101,7 @@ func NewNotificationRuleResource() resource.Resource {
 
 // notificationRuleResource is the resource implementation
 type notificationRuleResource struct {
-	client *dt.Client
+	client *providerClient
 }
 
 // Metadata returns the resource type name.
@@ -104,13 +110,49 @@ func (r *notificationRuleResource) Metadata(_ context.Context, req resource.Meta
 }
 
 func (r *notificationRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	// Import by the resource name, {project_id}/{display_name}, or by the identity in an import block
+	importStateByNameOrIdentity(ctx, req, resp, &notificationRuleIdentityModel{}, r.resolveImportID)
+}
+
+// resolveImportID resolves an import ID on the form {project_id}/{display_name} to the name of the rule.
+func (r *notificationRuleResource) resolveImportID(ctx context.Context, id string) (string, error) {
+	parts, err := splitImportID(id, "project_id", "display_name")
+	if err != nil {
+		return "", err
+	}
+	rules, err := r.client.ListNotificationRules(ctx, parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	candidates := make([]importCandidate, 0, len(rules))
+	for _, rule := range rules {
+		candidates = append(candidates, importCandidate{name: rule.Name, key: rule.DisplayName})
+	}
+	return matchImportID("notification rule", parts[1], candidates)
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *notificationRuleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"project_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the project the notification rule belongs to.",
+			},
+			"rule_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the notification rule.",
+			},
+		},
+	}
 }
 
 // Schema defines the schema for the resource.
 func (r *notificationRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 removed the deprecated top-level actions, see UpgradeState.
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Computed: true,
@@ -131,8 +173,9 @@ func (r *notificationRuleResource) Schema(ctx context.Context, req resource.Sche
 				Description: "he display name of the rule that is visible in Studio.",
 			},
 			"project_id": schema.StringAttribute{
-				Required:    true,
-				Description: "The DT project ID of the rule.",
+				Optional:    true,
+				Computed:    true,
+				Description: "The DT project ID of the rule. Defaults to the provider's `default_project`.",
 			},
 			"devices": schema.ListAttribute{
 				Optional:    true,
@@ -413,12 +456,7 @@ func (r *notificationRuleResource) Schema(ctx context.Context, req resource.Sche
 								is 4 hours.`,
 				Validators: []validator.String{durationValidator},
 			},
-			"actions": schema.ListNestedAttribute{
-				Optional:           true,
-				DeprecationMessage: "Use the `escalation_levels` attribute instead.",
-				Description:        "The list of actions that will be executed when the trigger is met.",
-				NestedObject:       notificationAction,
-			},
+			"deletion_protection": deletionProtectionAttribute("rule"),
 		},
 	}
 }
@@ -502,9 +540,26 @@ var notificationAction = schema.NestedAttributeObject{
 					Description: "The client ID of the device.",
 				},
 				"client_secret": schema.StringAttribute{
-					Required:    true,
-					Description: "The client secret of the device.",
+					Optional:    true,
+					Description: "The client secret of the device. Exactly one of `client_secret` or `client_secret_wo` must be set.",
 					Sensitive:   true,
+					Validators: []validator.String{
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("client_secret_wo")),
+					},
+				},
+				"client_secret_wo": schema.StringAttribute{
+					Optional:  true,
+					WriteOnly: true,
+					Sensitive: true,
+					Description: "The client secret of the device, as a write-only attribute that is never stored in the plan or state. " +
+						"Requires Terraform 1.11 or later. Bump `client_secret_wo_version` to send a changed secret.",
+				},
+				"client_secret_wo_version": schema.Int64Attribute{
+					Optional:    true,
+					Description: "The version of `client_secret_wo`. Changing it sends the current value of `client_secret_wo` to DT.",
+					Validators: []validator.Int64{
+						int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_secret_wo")),
+					},
 				},
 				"company_name": schema.StringAttribute{
 					Required:    true,
@@ -587,6 +642,23 @@ var notificationAction = schema.NestedAttributeObject{
 					Sensitive:   true,
 					Description: "Use a custom secret to sign the data.",
 				},
+				"signature_secret_wo": schema.StringAttribute{
+					Optional:  true,
+					WriteOnly: true,
+					Sensitive: true,
+					Description: "Use a custom secret to sign the data, as a write-only attribute that is never stored in the plan or state. " +
+						"Requires Terraform 1.11 or later. Bump `signature_secret_wo_version` to send a changed secret.",
+					Validators: []validator.String{
+						stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("signature_secret")),
+					},
+				},
+				"signature_secret_wo_version": schema.Int64Attribute{
+					Optional:    true,
+					Description: "The version of `signature_secret_wo`. Changing it sends the current value of `signature_secret_wo` to DT.",
+					Validators: []validator.Int64{
+						int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("signature_secret_wo")),
+					},
+				},
 				"headers": schema.MapAttribute{
 					Optional:    true,
 					Description: "The headers to include in the webhook request.",
@@ -632,20 +704,40 @@ var notificationAction = schema.NestedAttributeObject{
 
 // Data model
 type notificationRuleModel struct {
-	Name                 types.String              `tfsdk:"name"`
-	Enabled              types.Bool                `tfsdk:"enabled"`
-	DisplayName          types.String              `tfsdk:"display_name"`
-	ProjectID            types.String              `tfsdk:"project_id"`
-	Devices              types.List                `tfsdk:"devices"`
-	DeviceLabels         types.Map                 `tfsdk:"device_labels"`
-	Trigger              *triggerModel             `tfsdk:"trigger"`
-	EscalationLevels     []escalationLevelModel    `tfsdk:"escalation_levels"`
-	Schedule             *scheduleModel            `tfsdk:"schedule"`
-	TriggerDelay         types.String              `tfsdk:"trigger_delay"`
-	ReminderNotification types.Bool                `tfsdk:"reminder_notification"`
-	ResolvedNotification types.Bool                `tfsdk:"resolved_notification"`
-	UnacknowledgeAfter   types.String              `tfsdk:"unacknowledge_after"`
-	Actions              []notificationActionModel `tfsdk:"actions"`
+	Name                 types.String           `tfsdk:"name"`
+	Enabled              types.Bool             `tfsdk:"enabled"`
+	DisplayName          types.String           `tfsdk:"display_name"`
+	ProjectID            types.String           `tfsdk:"project_id"`
+	Devices              types.List             `tfsdk:"devices"`
+	DeviceLabels         types.Map              `tfsdk:"device_labels"`
+	Trigger              *triggerModel          `tfsdk:"trigger"`
+	EscalationLevels     []escalationLevelModel `tfsdk:"escalation_levels"`
+	Schedule             *scheduleModel         `tfsdk:"schedule"`
+	TriggerDelay         types.String           `tfsdk:"trigger_delay"`
+	ReminderNotification types.Bool             `tfsdk:"reminder_notification"`
+	ResolvedNotification types.Bool             `tfsdk:"resolved_notification"`
+	UnacknowledgeAfter   types.String           `tfsdk:"unacknowledge_after"`
+	DeletionProtection   types.Bool             `tfsdk:"deletion_protection"`
+}
+
+// notificationRuleIdentityModel is the identity of the resource.
+type notificationRuleIdentityModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	RuleID    types.String `tfsdk:"rule_id"`
+}
+
+func (m *notificationRuleIdentityModel) name() string {
+	return dtapi.RuleName{ProjectID: m.ProjectID.ValueString(), RuleID: m.RuleID.ValueString()}.String()
+}
+
+func (m *notificationRuleIdentityModel) fromName(name string) error {
+	ruleName, err := dtapi.ParseRuleName(name)
+	if err != nil {
+		return err
+	}
+	m.ProjectID = types.StringValue(ruleName.ProjectID)
+	m.RuleID = types.StringValue(ruleName.RuleID)
+	return nil
 }
 
 type escalationLevelModel struct {
@@ -677,17 +769,19 @@ type emailConfigModel struct {
 }
 
 type corrigoConfigModel struct {
-	AssetID              types.String `tfsdk:"asset_id"`
-	TaskID               types.String `tfsdk:"task_id"`
-	CustomerID           types.String `tfsdk:"customer_id"`
-	ClientID             types.String `tfsdk:"client_id"`
-	ClientSecret         types.String `tfsdk:"client_secret"`
-	CompanyName          types.String `tfsdk:"company_name"`
-	SubTypeID            types.String `tfsdk:"sub_type_id"`
-	ContactName          types.String `tfsdk:"contact_name"`
-	ContactAddress       types.String `tfsdk:"contact_address"`
-	WorkOrderDescription types.String `tfsdk:"work_order_description"`
-	StudioDashboardURL   types.String `tfsdk:"studio_dashboard_url"`
+	AssetID               types.String `tfsdk:"asset_id"`
+	TaskID                types.String `tfsdk:"task_id"`
+	CustomerID            types.String `tfsdk:"customer_id"`
+	ClientID              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	ClientSecretWO        types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWOVersion types.Int64  `tfsdk:"client_secret_wo_version"`
+	CompanyName           types.String `tfsdk:"company_name"`
+	SubTypeID             types.String `tfsdk:"sub_type_id"`
+	ContactName           types.String `tfsdk:"contact_name"`
+	ContactAddress        types.String `tfsdk:"contact_address"`
+	WorkOrderDescription  types.String `tfsdk:"work_order_description"`
+	StudioDashboardURL    types.String `tfsdk:"studio_dashboard_url"`
 }
 
 type serviceChannelConfigModel struct {
@@ -698,9 +792,11 @@ type serviceChannelConfigModel struct {
 }
 
 type webhookConfigModel struct {
-	URL             types.String `tfsdk:"url"`
-	SignatureSecret types.String `tfsdk:"signature_secret"`
-	Headers         types.Map    `tfsdk:"headers"`
+	URL                      types.String `tfsdk:"url"`
+	SignatureSecret          types.String `tfsdk:"signature_secret"`
+	SignatureSecretWO        types.String `tfsdk:"signature_secret_wo"`
+	SignatureSecretWOVersion types.Int64  `tfsdk:"signature_secret_wo_version"`
+	Headers                  types.Map    `tfsdk:"headers"`
 }
 
 type phoneCallConfigModel struct {
@@ -777,7 +873,15 @@ func (r *notificationRuleResource) Create(ctx context.Context, req resource.Crea
 		ctx = tflog.SetField(ctx, "plan", plan.EscalationLevels[0].Actions[1].CorrigoConfig)
 	}
 
-	// Convert the data to the dt.NotificationRule
+	// Write-only secrets are only available in the config
+	var config notificationRuleModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	notificationRuleSecretsFromConfig(&plan, config)
+
+	// Convert the data to the dtapi.NotificationRule
 	toBeCreated, diags := stateToNotificationRule(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -800,6 +904,8 @@ func (r *notificationRuleResource) Create(ctx context.Context, req resource.Crea
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	notificationRuleSecretsToState(&state, plan)
+	state.DeletionProtection = deletionProtectionFromPrior(plan.DeletionProtection)
 
 	if len(plan.EscalationLevels[0].Actions) == 2 {
 		ctx = tflog.SetField(ctx, "state", state.EscalationLevels[0].Actions[1].CorrigoConfig)
@@ -812,6 +918,7 @@ func (r *notificationRuleResource) Create(ctx context.Context, req resource.Crea
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &notificationRuleIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -835,11 +942,14 @@ func (r *notificationRuleResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	// Convert the notification rule to the state model
+	prior := state
 	state, diags = notificationRuleToState(ctx, notificationRule)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	notificationRuleSecretsToState(&state, prior)
+	state.DeletionProtection = deletionProtectionFromPrior(prior.DeletionProtection)
 
 	// Set the state
 	diags = resp.State.Set(ctx, &state)
@@ -847,6 +957,7 @@ func (r *notificationRuleResource) Read(ctx context.Context, req resource.ReadRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &notificationRuleIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -859,6 +970,11 @@ func (r *notificationRuleResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, "dt_notification_rule", "destroyed")
+		return
+	}
+
 	// Delete the notification rule
 	err := r.client.DeleteNotificationRule(ctx, state.Name.ValueString())
 	if err != nil {
@@ -880,7 +996,15 @@ func (r *notificationRuleResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	// Convert the data to the dt.NotificationRule
+	// Write-only secrets are only available in the config
+	var config notificationRuleModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	notificationRuleSecretsFromConfig(&plan, config)
+
+	// Convert the data to the dtapi.NotificationRule
 	toBeUpdated, diags := stateToNotificationRule(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -903,6 +1027,8 @@ func (r *notificationRuleResource) Update(ctx context.Context, req resource.Upda
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	notificationRuleSecretsToState(&state, plan)
+	state.DeletionProtection = deletionProtectionFromPrior(plan.DeletionProtection)
 
 	// Set the state
 	diags = resp.State.Set(ctx, &state)
@@ -910,6 +1036,29 @@ func (r *notificationRuleResource) Update(ctx context.Context, req resource.Upda
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &notificationRuleIdentityModel{}, state.Name, &resp.Diagnostics)
+}
+
+// ValidateConfig checks the display name and device labels against the provider policy.
+func (r *notificationRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if r.client == nil || r.client.Policy == nil {
+		return
+	}
+	validateDisplayNamePolicy(ctx, r.client.Policy, req.Config, &resp.Diagnostics)
+	validateLabelsPolicy(ctx, r.client.Policy, req.Config, path.Root("device_labels"), &resp.Diagnostics)
+}
+
+// ModifyPlan fills in the provider's default project when project_id is not set.
+// Any planned change fails when the provider is read only, and destroying or
+// replacing a protected rule fails.
+func (r *notificationRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkReadOnly(ctx, r.client, "dt_notification_rule", nil, req, resp)
+	defer checkDeletionProtection(ctx, "dt_notification_rule", nil, req, resp)
+
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("project_id"), "default_project", r.client.DefaultProject, false)
 }
 
 // Configure adds the provider configured client to the resource.
@@ -920,19 +1069,68 @@ func (r *notificationRuleResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*dt.Client)
+	client, ok := req.ProviderData.(*providerClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Type",
-			fmt.Sprintf("Expected *dt.Client, got %T", req.ProviderData),
+			fmt.Sprintf("Expected *providerClient, got %T", req.ProviderData),
 		)
 		return
 	}
 	r.client = client
 }
 
-// NotificationRuleResource converts the dt.NotificationRule to the state model.
-func notificationRuleToState(ctx context.Context, notificationRule dt.NotificationRule) (notificationRuleModel, diag.Diagnostics) {
+// notificationRuleSecretsFromConfig copies the write-only secrets of the notification
+// actions from the config, as they are always null in the plan.
+func notificationRuleSecretsFromConfig(plan *notificationRuleModel, config notificationRuleModel) {
+	forEachActionPair(plan, config, func(action, configAction *notificationActionModel) {
+		if action.WebhookConfig != nil && configAction.WebhookConfig != nil {
+			action.WebhookConfig.SignatureSecretWO = configAction.WebhookConfig.SignatureSecretWO
+		}
+		if action.CorrigoConfig != nil && configAction.CorrigoConfig != nil {
+			action.CorrigoConfig.ClientSecretWO = configAction.CorrigoConfig.ClientSecretWO
+		}
+	})
+}
+
+// notificationRuleSecretsToState keeps the secrets returned by the API out of the state
+// when they are not set in the prior plan or state, such as when the write-only
+// attribute is used, and carries over the secret versions.
+func notificationRuleSecretsToState(state *notificationRuleModel, prior notificationRuleModel) {
+	forEachActionPair(state, prior, func(action, priorAction *notificationActionModel) {
+		if action.WebhookConfig != nil && priorAction.WebhookConfig != nil {
+			action.WebhookConfig.SignatureSecretWOVersion = priorAction.WebhookConfig.SignatureSecretWOVersion
+			if priorAction.WebhookConfig.SignatureSecret.IsNull() {
+				action.WebhookConfig.SignatureSecret = types.StringNull()
+			}
+		}
+		if action.CorrigoConfig != nil && priorAction.CorrigoConfig != nil {
+			action.CorrigoConfig.ClientSecretWOVersion = priorAction.CorrigoConfig.ClientSecretWOVersion
+			if priorAction.CorrigoConfig.ClientSecret.IsNull() {
+				action.CorrigoConfig.ClientSecret = types.StringNull()
+			}
+		}
+	})
+}
+
+// forEachActionPair calls fn with the notification actions of each escalation
+// level of the rule and the actions at the same position in the other rule.
+func forEachActionPair(rule *notificationRuleModel, other notificationRuleModel, fn func(action, otherAction *notificationActionModel)) {
+	for i := range rule.EscalationLevels {
+		if i >= len(other.EscalationLevels) {
+			break
+		}
+		actions, otherActions := rule.EscalationLevels[i].Actions, other.EscalationLevels[i].Actions
+		for j := range actions {
+			if j < len(otherActions) {
+				fn(&actions[j], &otherActions[j])
+			}
+		}
+	}
+}
+
+// NotificationRuleResource converts the dtapi.NotificationRule to the state model.
+func notificationRuleToState(ctx context.Context, notificationRule dtapi.NotificationRule) (notificationRuleModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	devicesList, d := types.ListValueFrom(ctx, types.StringType, notificationRule.Devices)
 	diags = append(diags, d...)
@@ -946,15 +1144,12 @@ func notificationRuleToState(ctx context.Context, notificationRule dt.Notificati
 	escalationLevels, d := escalationLevelToState(ctx, notificationRule.EscalationLevels)
 	diags = append(diags, d...)
 
-	actions, d := notificationActionToState(ctx, notificationRule.Actions) // nolint: staticcheck // Actions is deprecated, but included for completeness.
-	diags = append(diags, d...)
-
 	// Convert the notification rule to the state model.
 	var state notificationRuleModel
 
 	state.Name = types.StringValue(notificationRule.Name)
 
-	projectID, _, err := dt.ParseResourceName(notificationRule.Name)
+	ruleName, err := dtapi.ParseRuleName(notificationRule.Name)
 	if err != nil {
 		diags.AddError(
 			"Error parsing notification rule name",
@@ -962,7 +1157,7 @@ func notificationRuleToState(ctx context.Context, notificationRule dt.Notificati
 		)
 		return state, diags
 	}
-	state.ProjectID = types.StringValue(projectID)
+	state.ProjectID = types.StringValue(ruleName.ProjectID)
 	state.Enabled = types.BoolValue(notificationRule.Enabled)
 	state.DisplayName = types.StringValue(notificationRule.DisplayName)
 	state.Devices = devicesList
@@ -985,12 +1180,12 @@ func notificationRuleToState(ctx context.Context, notificationRule dt.Notificati
 	if notificationRule.UnacknowledgesAfter != nil {
 		state.UnacknowledgeAfter = types.StringValue(*notificationRule.UnacknowledgesAfter)
 	}
-	state.Actions = actions
+	state.DeletionProtection = types.BoolValue(false)
 	return state, diags
 }
 
-// escalationLevelToState converts the dt.EscalationLevel to the state model.
-func escalationLevelToState(ctx context.Context, dtEscalationLevel []dt.EscalationLevel) ([]escalationLevelModel, diag.Diagnostics) {
+// escalationLevelToState converts the dtapi.EscalationLevel to the state model.
+func escalationLevelToState(ctx context.Context, dtEscalationLevel []dtapi.EscalationLevel) ([]escalationLevelModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	escalationLevels := make([]escalationLevelModel, 0, len(dtEscalationLevel))
 	for _, level := range dtEscalationLevel {
@@ -1014,8 +1209,8 @@ func escalationLevelToState(ctx context.Context, dtEscalationLevel []dt.Escalati
 	return escalationLevels, diags
 }
 
-// notificationActionToState converts the dt.Action to the state model.
-func notificationActionToState(ctx context.Context, dtNotificationActions []dt.NotificationAction) ([]notificationActionModel, diag.Diagnostics) {
+// notificationActionToState converts the dtapi.Action to the state model.
+func notificationActionToState(ctx context.Context, dtNotificationActions []dtapi.NotificationAction) ([]notificationActionModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if len(dtNotificationActions) == 0 {
 		return nil, diags
@@ -1051,7 +1246,7 @@ func notificationActionToState(ctx context.Context, dtNotificationActions []dt.N
 	return notificationActions, diags
 }
 
-func smsConfigToState(ctx context.Context, smsConfig *dt.SMSConfig) (*smsConfigModel, diag.Diagnostics) {
+func smsConfigToState(ctx context.Context, smsConfig *dtapi.SMSConfig) (*smsConfigModel, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 	if smsConfig == nil {
 		return nil, diags
@@ -1066,7 +1261,7 @@ func smsConfigToState(ctx context.Context, smsConfig *dt.SMSConfig) (*smsConfigM
 	}, diags
 }
 
-func emailConfigToState(ctx context.Context, emailConfig *dt.EmailConfig) (*emailConfigModel, diag.Diagnostics) {
+func emailConfigToState(ctx context.Context, emailConfig *dtapi.EmailConfig) (*emailConfigModel, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 	if emailConfig == nil {
 		return nil, diags
@@ -1087,7 +1282,7 @@ func emailConfigToState(ctx context.Context, emailConfig *dt.EmailConfig) (*emai
 	}, diags
 }
 
-func corrigoConfigToState(corrigoConfig *dt.CorrigoConfig) *corrigoConfigModel {
+func corrigoConfigToState(corrigoConfig *dtapi.CorrigoConfig) *corrigoConfigModel {
 	if corrigoConfig == nil {
 		return nil
 	}
@@ -1107,7 +1302,7 @@ func corrigoConfigToState(corrigoConfig *dt.CorrigoConfig) *corrigoConfigModel {
 	}
 }
 
-func serviceChannelConfigToState(serviceChannelConfig *dt.ServiceChannelConfig) *serviceChannelConfigModel {
+func serviceChannelConfigToState(serviceChannelConfig *dtapi.ServiceChannelConfig) *serviceChannelConfigModel {
 	if serviceChannelConfig == nil {
 		return nil
 	}
@@ -1120,7 +1315,7 @@ func serviceChannelConfigToState(serviceChannelConfig *dt.ServiceChannelConfig)
 	}
 }
 
-func webhookConfigToState(ctx context.Context, webhookConfig *dt.WebhookConfig) (*webhookConfigModel, diag.Diagnostics) {
+func webhookConfigToState(ctx context.Context, webhookConfig *dtapi.WebhookConfig) (*webhookConfigModel, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 	if webhookConfig == nil {
 		return nil, diags
@@ -1136,7 +1331,7 @@ func webhookConfigToState(ctx context.Context, webhookConfig *dt.WebhookConfig)
 	}, diags
 }
 
-func phoneCallConfigToState(ctx context.Context, phoneCallConfig *dt.PhoneCallConfig) (*phoneCallConfigModel, diag.Diagnostics) {
+func phoneCallConfigToState(ctx context.Context, phoneCallConfig *dtapi.PhoneCallConfig) (*phoneCallConfigModel, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 	if phoneCallConfig == nil {
 		return nil, diags
@@ -1152,7 +1347,7 @@ func phoneCallConfigToState(ctx context.Context, phoneCallConfig *dt.PhoneCallCo
 	}, diags
 }
 
-func signalTowerConfigToState(signalTowerConfig *dt.SignalTowerConfig) *signalTowerConfigModel {
+func signalTowerConfigToState(signalTowerConfig *dtapi.SignalTowerConfig) *signalTowerConfigModel {
 	if signalTowerConfig == nil {
 		return nil
 	}
@@ -1162,7 +1357,7 @@ func signalTowerConfigToState(signalTowerConfig *dt.SignalTowerConfig) *signalTo
 	}
 }
 
-func scheduleToState(schedule *dt.Schedule) *scheduleModel {
+func scheduleToState(schedule *dtapi.Schedule) *scheduleModel {
 	if schedule == nil {
 		return nil
 	}
@@ -1196,7 +1391,7 @@ func scheduleToState(schedule *dt.Schedule) *scheduleModel {
 	return &scheduleModel
 }
 
-func triggerToState(ctx context.Context, trigger dt.Trigger) (*triggerModel, diag.Diagnostics) {
+func triggerToState(ctx context.Context, trigger dtapi.Trigger) (*triggerModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	model := triggerModel{
@@ -1266,7 +1461,7 @@ func triggerToState(ctx context.Context, trigger dt.Trigger) (*triggerModel, dia
 	return &model, diags
 }
 
-func stateToNotificationRule(ctx context.Context, state notificationRuleModel) (dt.NotificationRule, diag.Diagnostics) {
+func stateToNotificationRule(ctx context.Context, state notificationRuleModel) (dtapi.NotificationRule, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	devices, d := expandStringList(ctx, state.Devices)
 	diags = append(diags, d...)
@@ -1281,14 +1476,11 @@ func stateToNotificationRule(ctx context.Context, state notificationRuleModel) (
 	escalationLevels, d := stateToEscalationLevels(ctx, state.EscalationLevels)
 	diags = append(diags, d...)
 
-	actions, d := stateToNotificationAction(ctx, state.Actions)
-	diags = append(diags, d...)
-
 	triggerDelay := state.TriggerDelay.ValueStringPointer()
 	unacknowledgeAfter := state.UnacknowledgeAfter.ValueStringPointer()
 	schedule := stateToSchedule(state.Schedule)
 
-	return dt.NotificationRule{
+	return dtapi.NotificationRule{
 		Name:                 state.Name.ValueString(),
 		Enabled:              state.Enabled.ValueBool(),
 		DisplayName:          state.DisplayName.ValueString(),
@@ -1301,16 +1493,15 @@ func stateToNotificationRule(ctx context.Context, state notificationRuleModel) (
 		ReminderNotification: state.ReminderNotification.ValueBool(),
 		ResolvedNotification: state.ResolvedNotification.ValueBool(),
 		UnacknowledgesAfter:  unacknowledgeAfter,
-		Actions:              actions,
 	}, diags
 }
 
-func stateToTrigger(ctx context.Context, state *triggerModel) (dt.Trigger, diag.Diagnostics) {
+func stateToTrigger(ctx context.Context, state *triggerModel) (dtapi.Trigger, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if state == nil {
-		return dt.Trigger{}, diags
+		return dtapi.Trigger{}, diags
 	}
-	trigger := dt.Trigger{
+	trigger := dtapi.Trigger{
 		Field:        state.Field.ValueString(),
 		Presence:     state.Presence.ValueStringPointer(),
 		Motion:       state.Motion.ValueStringPointer(),
@@ -1325,7 +1516,7 @@ func stateToTrigger(ctx context.Context, state *triggerModel) (dt.Trigger, diag.
 		return trigger, diags
 	}
 
-	trigger.Range = &dt.Range{
+	trigger.Range = &dtapi.Range{
 		Lower: state.Range.Lower.ValueFloat64Pointer(),
 		Upper: state.Range.Upper.ValueFloat64Pointer(),
 		Type:  state.Range.Type.ValueString(),
@@ -1346,23 +1537,23 @@ func stateToTrigger(ctx context.Context, state *triggerModel) (dt.Trigger, diag.
 		return trigger, diags
 	}
 
-	trigger.Range.Filter = &dt.Filter{
+	trigger.Range.Filter = &dtapi.Filter{
 		ProductEquivalentTemperature: &struct{}{},
 	}
 
 	return trigger, diags
 }
 
-func stateToEscalationLevels(ctx context.Context, state []escalationLevelModel) ([]dt.EscalationLevel, diag.Diagnostics) {
+func stateToEscalationLevels(ctx context.Context, state []escalationLevelModel) ([]dtapi.EscalationLevel, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	levels := []dt.EscalationLevel{}
+	levels := []dtapi.EscalationLevel{}
 	for _, level := range state {
 		actions, d := stateToNotificationAction(ctx, level.Actions)
 		diags = append(diags, d...)
 
 		escalateAfter := level.EscalateAfter.ValueStringPointer()
 
-		levels = append(levels, dt.EscalationLevel{
+		levels = append(levels, dtapi.EscalationLevel{
 			DisplayName:   level.DisplayName.ValueString(),
 			Actions:       actions,
 			EscalateAfter: escalateAfter,
@@ -1372,13 +1563,13 @@ func stateToEscalationLevels(ctx context.Context, state []escalationLevelModel)
 	return levels, diags
 }
 
-func stateToNotificationAction(ctx context.Context, state []notificationActionModel) ([]dt.NotificationAction, diag.Diagnostics) {
+func stateToNotificationAction(ctx context.Context, state []notificationActionModel) ([]dtapi.NotificationAction, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if len(state) == 0 {
 		return nil, diags
 	}
 
-	actions := make([]dt.NotificationAction, 0, len(state))
+	actions := make([]dtapi.NotificationAction, 0, len(state))
 	for _, action := range state {
 		smsConfig, d := stateToSMSConfig(ctx, action.SMSConfig)
 		diags = append(diags, d...)
@@ -1392,7 +1583,7 @@ func stateToNotificationAction(ctx context.Context, state []notificationActionMo
 		phoneCallConfig, d := stateToPhoneCallConfig(ctx, action.PhoneCallConfig)
 		diags = append(diags, d...)
 
-		actions = append(actions, dt.NotificationAction{
+		actions = append(actions, dtapi.NotificationAction{
 			Type:                 action.Type.ValueString(),
 			SMSConfig:            smsConfig,
 			EmailConfig:          emailConfig,
@@ -1407,7 +1598,7 @@ func stateToNotificationAction(ctx context.Context, state []notificationActionMo
 	return actions, diags
 }
 
-func stateToSMSConfig(ctx context.Context, state *smsConfigModel) (*dt.SMSConfig, diag.Diagnostics) {
+func stateToSMSConfig(ctx context.Context, state *smsConfigModel) (*dtapi.SMSConfig, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if state == nil {
 		return nil, nil
@@ -1416,13 +1607,13 @@ func stateToSMSConfig(ctx context.Context, state *smsConfigModel) (*dt.SMSConfig
 	recipients, d := expandStringList(ctx, state.Recipients)
 	diags = append(diags, d...)
 
-	return &dt.SMSConfig{
+	return &dtapi.SMSConfig{
 		Recipients: recipients,
 		Body:       state.Body.ValueString(),
 	}, diags
 }
 
-func stateToEmailConfig(ctx context.Context, state *emailConfigModel) (*dt.EmailConfig, diag.Diagnostics) {
+func stateToEmailConfig(ctx context.Context, state *emailConfigModel) (*dtapi.EmailConfig, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if state == nil {
@@ -1432,24 +1623,24 @@ func stateToEmailConfig(ctx context.Context, state *emailConfigModel) (*dt.Email
 	recipients, d := expandStringList(ctx, state.Recipients)
 	diags = append(diags, d...)
 
-	return &dt.EmailConfig{
+	return &dtapi.EmailConfig{
 		Recipients: recipients,
 		Subject:    state.Subject.ValueString(),
 		Body:       state.Body.ValueString(),
 	}, diags
 }
 
-func stateToCorrigoConfig(state *corrigoConfigModel) *dt.CorrigoConfig {
+func stateToCorrigoConfig(state *corrigoConfigModel) *dtapi.CorrigoConfig {
 	if state == nil {
 		return nil
 	}
 
-	return &dt.CorrigoConfig{
+	return &dtapi.CorrigoConfig{
 		AssetID:              state.AssetID.ValueString(),
 		TaskID:               state.TaskID.ValueString(),
 		CustomerID:           state.CustomerID.ValueString(),
 		ClientID:             state.ClientID.ValueString(),
-		ClientSecret:         state.ClientSecret.ValueString(),
+		ClientSecret:         writeOnlyOr(state.ClientSecretWO, state.ClientSecret),
 		CompanyName:          state.CompanyName.ValueString(),
 		SubTypeID:            state.SubTypeID.ValueString(),
 		ContactName:          state.ContactName.ValueString(),
@@ -1459,12 +1650,12 @@ func stateToCorrigoConfig(state *corrigoConfigModel) *dt.CorrigoConfig {
 	}
 }
 
-func stateToServiceChannelConfig(state *serviceChannelConfigModel) *dt.ServiceChannelConfig {
+func stateToServiceChannelConfig(state *serviceChannelConfigModel) *dtapi.ServiceChannelConfig {
 	if state == nil {
 		return nil
 	}
 
-	return &dt.ServiceChannelConfig{
+	return &dtapi.ServiceChannelConfig{
 		StoreID:     state.StoreID.ValueString(),
 		AssetTagID:  state.AssetTagID.ValueString(),
 		Trade:       state.Trade.ValueString(),
@@ -1472,7 +1663,7 @@ func stateToServiceChannelConfig(state *serviceChannelConfigModel) *dt.ServiceCh
 	}
 }
 
-func stateToWebhookConfig(ctx context.Context, state *webhookConfigModel) (*dt.WebhookConfig, diag.Diagnostics) {
+func stateToWebhookConfig(ctx context.Context, state *webhookConfigModel) (*dtapi.WebhookConfig, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if state == nil {
 		return nil, nil
@@ -1485,14 +1676,14 @@ func stateToWebhookConfig(ctx context.Context, state *webhookConfigModel) (*dt.W
 		return nil, diags
 	}
 
-	return &dt.WebhookConfig{
+	return &dtapi.WebhookConfig{
 		URL:             state.URL.ValueString(),
-		SignatureSecret: state.SignatureSecret.ValueString(),
+		SignatureSecret: writeOnlyOr(state.SignatureSecretWO, state.SignatureSecret),
 		Headers:         headers,
 	}, nil
 }
 
-func stateToPhoneCallConfig(ctx context.Context, state *phoneCallConfigModel) (*dt.PhoneCallConfig, diag.Diagnostics) {
+func stateToPhoneCallConfig(ctx context.Context, state *phoneCallConfigModel) (*dtapi.PhoneCallConfig, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if state == nil {
 		return nil, nil
@@ -1501,30 +1692,30 @@ func stateToPhoneCallConfig(ctx context.Context, state *phoneCallConfigModel) (*
 	recipients, d := expandStringList(ctx, state.Recipients)
 	diags = append(diags, d...)
 
-	return &dt.PhoneCallConfig{
+	return &dtapi.PhoneCallConfig{
 		Recipients:   recipients,
 		Introduction: state.Introduction.ValueString(),
 		Message:      state.Message.ValueString(),
 	}, diags
 }
 
-func stateToSignalTowerConfig(state *signalTowerConfigModel) *dt.SignalTowerConfig {
+func stateToSignalTowerConfig(state *signalTowerConfigModel) *dtapi.SignalTowerConfig {
 	if state == nil {
 		return nil
 	}
 
-	return &dt.SignalTowerConfig{
+	return &dtapi.SignalTowerConfig{
 		CloudConnectorName: state.CloudConnectorName.ValueString(),
 	}
 }
 
-func stateToSchedule(state *scheduleModel) *dt.Schedule {
+func stateToSchedule(state *scheduleModel) *dtapi.Schedule {
 	if state == nil {
 		return nil
 	}
-	schedule := dt.Schedule{
+	schedule := dtapi.Schedule{
 		Timezone: state.Timezone.ValueString(),
-		Slots:    make([]dt.Slot, len(state.Slots)),
+		Slots:    make([]dtapi.Slot, len(state.Slots)),
 		Inverse:  state.Inverse.ValueBool(),
 	}
 
@@ -1534,21 +1725,21 @@ func stateToSchedule(state *scheduleModel) *dt.Schedule {
 			daysOfWeek[dayIndex] = day.ValueString()
 		}
 
-		timeRanges := make([]dt.TimeRange, len(slot.TimeRange))
+		timeRanges := make([]dtapi.TimeRange, len(slot.TimeRange))
 		for timeRangeIndex, timeRange := range slot.TimeRange {
-			timeRanges[timeRangeIndex] = dt.TimeRange{
-				Start: dt.TimeOfDay{
+			timeRanges[timeRangeIndex] = dtapi.TimeRange{
+				Start: dtapi.TimeOfDay{
 					Hour:   timeRange.Start.Hour.ValueInt32(),
 					Minute: timeRange.Start.Minute.ValueInt32(),
 				},
-				End: dt.TimeOfDay{
+				End: dtapi.TimeOfDay{
 					Hour:   timeRange.End.Hour.ValueInt32(),
 					Minute: timeRange.End.Minute.ValueInt32(),
 				},
 			}
 		}
 
-		schedule.Slots[slotIndex] = dt.Slot{
+		schedule.Slots[slotIndex] = dtapi.Slot{
 			DaysOfWeek: daysOfWeek,
 			TimeRange:  timeRanges,
 		}
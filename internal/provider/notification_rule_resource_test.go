@@ -3,8 +3,22 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -55,7 +69,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/with_schedule.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Notification Rule Acceptance Test"),
+					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Acceptance Test notification rule"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "device_labels.%", "1"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "device_labels.foo", "bar"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "resolved_notification", "true"),
@@ -88,7 +102,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/email_sms_escalation.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Notification Rule Acceptance Test Updated"),
+					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Acceptance Test notification rule updated"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.field", "temperature"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.range.lower", "0"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.range.upper", "35"),
@@ -118,7 +132,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/ccon_offline_trigger.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Cloud connector offline"),
+					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Acceptance Test cloud connector offline"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.field", "connectionStatus"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.connection", "CLOUD_CONNECTOR_OFFLINE"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "escalation_levels.0.display_name", "Escalation Level 1"),
@@ -140,7 +154,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/sensor_offline_trigger.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Sensor offline"),
+					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Acceptance Test sensor offline"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.field", "connectionStatus"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.connection", "SENSOR_OFFLINE"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger_delay", "900s"),
@@ -163,7 +177,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/disabled_rule.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "display_name", "Disabled notification rule"),
+					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "display_name", "Acceptance Test disabled notification rule"),
 					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "enabled", "false"),
 					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "trigger.field", "temperature"),
 					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "trigger.range.lower", "0"),
@@ -188,7 +202,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/reminder_notification.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "With reminder notification"),
+					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Acceptance Test reminder notification"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.field", "relativeHumidity"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.range.lower", "30"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.range.upper", "70"),
@@ -212,7 +226,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/all_escalations.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "All escalation types"),
+					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Acceptance Test all escalation types"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.field", "temperature"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.range.lower", "0"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.range.type", "OUTSIDE"),
@@ -290,7 +304,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/signal_tower.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Signal Tower"),
+					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Acceptance Test signal tower"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.field", "connectionStatus"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "trigger.connection", "CLOUD_CONNECTOR_OFFLINE"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "escalation_levels.#", "1"),
@@ -311,7 +325,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/inverse_schedule.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Off Hours Schedule"),
+					resource.TestCheckResourceAttr("dt_notification_rule.test", "display_name", "Acceptance Test off hours schedule"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "schedule.timezone", "Europe/Oslo"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "schedule.slots.#", "2"),
 					resource.TestCheckResourceAttr("dt_notification_rule.test", "schedule.slots.0.day_of_week.#", "5"),
@@ -346,7 +360,7 @@ func TestAccNotificationRuleResource(t *testing.T) {
 			{
 				Config: notificationRuleProviderConfig + readTestFile(t, "../../testdata/notification_rule/pet_filter.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "display_name", "Range with PET filter"),
+					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "display_name", "Acceptance Test range with PET filter"),
 					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "enabled", "true"),
 					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "trigger.field", "temperature"),
 					resource.TestCheckResourceAttr("dt_notification_rule.my_notification_rule", "trigger.range.lower", "-10"),
@@ -368,3 +382,266 @@ func TestAccNotificationRuleResource(t *testing.T) {
 		},
 	})
 }
+
+func TestNotificationRuleWriteOnlySecrets(t *testing.T) {
+	t.Parallel()
+
+	webhook := func(secret, secretWO types.String, version types.Int64) []notificationActionModel {
+		return []notificationActionModel{{WebhookConfig: &webhookConfigModel{
+			SignatureSecret:          secret,
+			SignatureSecretWO:        secretWO,
+			SignatureSecretWOVersion: version,
+		}}}
+	}
+	rule := func(actions []notificationActionModel) notificationRuleModel {
+		return notificationRuleModel{EscalationLevels: []escalationLevelModel{{Actions: actions}}}
+	}
+
+	// the write-only secret is only present in the config
+	plan := rule(webhook(types.StringNull(), types.StringNull(), types.Int64Value(2)))
+	config := rule(webhook(types.StringNull(), types.StringValue("from-vault"), types.Int64Value(2)))
+	notificationRuleSecretsFromConfig(&plan, config)
+	if got := writeOnlyOr(plan.EscalationLevels[0].Actions[0].WebhookConfig.SignatureSecretWO, types.StringNull()); got != "from-vault" {
+		t.Errorf("expected the write-only secret from the config, got %q", got)
+	}
+
+	// the secret returned by the API is kept out of the state, and the version is kept
+	state := rule(webhook(types.StringValue("from-vault"), types.StringNull(), types.Int64Null()))
+	notificationRuleSecretsToState(&state, plan)
+	webhookConfig := state.EscalationLevels[0].Actions[0].WebhookConfig
+	if !webhookConfig.SignatureSecret.IsNull() || !webhookConfig.SignatureSecretWO.IsNull() {
+		t.Errorf("expected no secrets in the state, got %s and %s", webhookConfig.SignatureSecret, webhookConfig.SignatureSecretWO)
+	}
+	if !webhookConfig.SignatureSecretWOVersion.Equal(types.Int64Value(2)) {
+		t.Errorf("expected version 2, got %s", webhookConfig.SignatureSecretWOVersion)
+	}
+
+	// a secret set with the sensitive attribute is still stored
+	state = rule(webhook(types.StringValue("secret"), types.StringNull(), types.Int64Null()))
+	notificationRuleSecretsToState(&state, rule(webhook(types.StringValue("secret"), types.StringNull(), types.Int64Null())))
+	if got := state.EscalationLevels[0].Actions[0].WebhookConfig.SignatureSecret; !got.Equal(types.StringValue("secret")) {
+		t.Errorf("expected the secret to be kept, got %s", got)
+	}
+}
+
+func TestNotificationRuleUpgradeStateV0(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stateFile        string
+		escalationLevels []escalationLevelModel
+	}{
+		"top-level actions": {
+			stateFile: "../../testdata/notification_rule/state/v0_actions.json",
+			escalationLevels: []escalationLevelModel{{
+				DisplayName:   types.StringValue(legacyEscalationLevelName),
+				EscalateAfter: types.StringNull(),
+				Actions: []notificationActionModel{
+					{Type: types.StringValue("EMAIL")},
+					{Type: types.StringValue("WEBHOOK")},
+				},
+			}},
+		},
+		"escalation levels": {
+			stateFile: "../../testdata/notification_rule/state/v0_escalation_levels.json",
+			escalationLevels: []escalationLevelModel{{
+				DisplayName:   types.StringValue("On call"),
+				EscalateAfter: types.StringValue("3600s"),
+				Actions: []notificationActionModel{
+					{Type: types.StringValue("SMS")},
+					{Type: types.StringValue("CORRIGO")},
+				},
+			}},
+		},
+	}
+
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	(&notificationRuleResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rawState, err := os.ReadFile(test.stateFile)
+			if err != nil {
+				t.Fatalf("failed to read state file: %v", err)
+			}
+			server, err := providerserver.NewProtocol6WithError(New("test")())()
+			if err != nil {
+				t.Fatalf("failed to create provider server: %v", err)
+			}
+			resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: "dt_notification_rule",
+				Version:  0,
+				RawState: &tfprotov6.RawState{JSON: rawState},
+			})
+			if err != nil {
+				t.Fatalf("failed to upgrade state: %v", err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+			if t.Failed() {
+				return
+			}
+
+			raw, err := resp.UpgradedState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+			if err != nil {
+				t.Fatalf("failed to unmarshal upgraded state: %v", err)
+			}
+			var state notificationRuleModel
+			diags := tfsdk.State{Schema: schemaResp.Schema, Raw: raw}.Get(ctx, &state)
+			if diags.HasError() {
+				t.Fatalf("failed to read upgraded state: %v", diags)
+			}
+
+			if got := state.DisplayName.ValueString(); got != "Freezer alarm" {
+				t.Errorf("expected the display name to be kept, got %q", got)
+			}
+			if len(state.EscalationLevels) != len(test.escalationLevels) {
+				t.Fatalf("expected %d escalation levels, got %d", len(test.escalationLevels), len(state.EscalationLevels))
+			}
+			for i, want := range test.escalationLevels {
+				got := state.EscalationLevels[i]
+				if !got.DisplayName.Equal(want.DisplayName) || !got.EscalateAfter.Equal(want.EscalateAfter) {
+					t.Errorf("escalation level %d: expected %s after %s, got %s after %s", i, want.DisplayName, want.EscalateAfter, got.DisplayName, got.EscalateAfter)
+				}
+				if len(got.Actions) != len(want.Actions) {
+					t.Fatalf("escalation level %d: expected %d actions, got %d", i, len(want.Actions), len(got.Actions))
+				}
+				for j := range want.Actions {
+					if !got.Actions[j].Type.Equal(want.Actions[j].Type) {
+						t.Errorf("escalation level %d action %d: expected type %s, got %s", i, j, want.Actions[j].Type, got.Actions[j].Type)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationRuleSchemaV0(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaV0 := notificationRuleSchemaV0()
+
+	for _, stateFile := range []string{
+		"../../testdata/notification_rule/state/v0_actions.json",
+		"../../testdata/notification_rule/state/v0_escalation_levels.json",
+	} {
+		t.Run(filepath.Base(stateFile), func(t *testing.T) {
+			t.Parallel()
+
+			rawState, err := os.ReadFile(stateFile)
+			if err != nil {
+				t.Fatalf("failed to read state file: %v", err)
+			}
+			var state any
+			if err := json.Unmarshal(rawState, &state); err != nil {
+				t.Fatalf("failed to decode state file: %v", err)
+			}
+			compareStateAttributes(t, "", state, schemaV0.Type().TerraformType(ctx))
+
+			raw, err := (&tfprotov6.RawState{JSON: rawState}).UnmarshalWithOpts(
+				schemaV0.Type().TerraformType(ctx),
+				tfprotov6.UnmarshalOpts{ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: false}},
+			)
+			if err != nil {
+				t.Fatalf("failed to unmarshal state against the version 0 schema: %v", err)
+			}
+			var prior notificationRuleModelV0
+			if diags := (tfsdk.State{Schema: schemaV0, Raw: raw}).Get(ctx, &prior); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags)
+			}
+			if _, diags := upgradeNotificationRuleV0(ctx, prior); diags.HasError() {
+				t.Errorf("failed to upgrade state: %v", diags)
+			}
+		})
+	}
+}
+
+// compareStateAttributes reports the objects in the JSON state whose attributes
+// differ from the attributes of the schema type.
+func compareStateAttributes(t *testing.T, path string, value any, typ tftypes.Type) {
+	t.Helper()
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for name := range object {
+			if _, ok := typ.AttributeTypes[name]; !ok {
+				t.Errorf("%s%s: not defined by the schema", path, name)
+			}
+		}
+		for name, attributeType := range typ.AttributeTypes {
+			if _, ok := object[name]; !ok {
+				t.Errorf("%s%s: missing from the state", path, name)
+				continue
+			}
+			compareStateAttributes(t, path+name+".", object[name], attributeType)
+		}
+	case tftypes.List:
+		elements, _ := value.([]any)
+		for i, element := range elements {
+			compareStateAttributes(t, fmt.Sprintf("%s%d.", path, i), element, typ.ElementType)
+		}
+	}
+}
+
+func TestNotificationRuleList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		case "/v2alpha/projects/p1/rules":
+			fmt.Fprint(w, `{"rules":[
+				{"name":"projects/p1/rules/r1","enabled":true,"displayName":"Fridge alarm","deviceLabels":{"room":"kitchen"},"trigger":{"field":"temperature","range":{"upper":8}},"escalationLevels":[]},
+				{"name":"projects/p1/rules/r2","enabled":false,"displayName":"Freezer alarm","deviceLabels":{"room":"cellar"},"trigger":{"field":"temperature","range":{"upper":-18}},"escalationLevels":[]}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	labelsType := tftypes.Map{ElementType: tftypes.String}
+	tests := map[string]struct {
+		config map[string]tftypes.Value
+		limit  int64
+		want   []string
+	}{
+		"default project": {want: []string{"Fridge alarm", "Freezer alarm"}},
+		"display name": {
+			config: map[string]tftypes.Value{"display_name": tftypes.NewValue(tftypes.String, "Freezer alarm")},
+			want:   []string{"Freezer alarm"},
+		},
+		"labels": {
+			config: map[string]tftypes.Value{
+				"labels": tftypes.NewValue(labelsType, map[string]tftypes.Value{"room": tftypes.NewValue(tftypes.String, "cellar")}),
+			},
+			want: []string{"Freezer alarm"},
+		},
+		"display name and labels": {
+			config: map[string]tftypes.Value{
+				"display_name": tftypes.NewValue(tftypes.String, "Fridge alarm"),
+				"labels":       tftypes.NewValue(labelsType, map[string]tftypes.Value{"room": tftypes.NewValue(tftypes.String, "cellar")}),
+			},
+		},
+		"limit": {limit: 1, want: []string{"Fridge alarm"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := listResource(t, "dt_notification_rule", testServerProviderConfig(t, server.URL), tt.config, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
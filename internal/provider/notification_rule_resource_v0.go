@@ -0,0 +1,356 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// legacyEscalationLevelName is the display name of the escalation level that the
+// deprecated top-level actions are moved into when upgrading from version 0.
+const legacyEscalationLevelName = "Escalation Level 1"
+
+// notificationRuleModelV0 is the data model of schema version 0. Nested
+// attributes are kept as values, so that reading version 0 state doesn't
+// depend on the models of the current schema.
+type notificationRuleModelV0 struct {
+	Name                 types.String `tfsdk:"name"`
+	Enabled              types.Bool   `tfsdk:"enabled"`
+	DisplayName          types.String `tfsdk:"display_name"`
+	ProjectID            types.String `tfsdk:"project_id"`
+	Devices              types.List   `tfsdk:"devices"`
+	DeviceLabels         types.Map    `tfsdk:"device_labels"`
+	Trigger              types.Object `tfsdk:"trigger"`
+	Actions              types.List   `tfsdk:"actions"`
+	EscalationLevels     types.List   `tfsdk:"escalation_levels"`
+	Schedule             types.Object `tfsdk:"schedule"`
+	TriggerDelay         types.String `tfsdk:"trigger_delay"`
+	ReminderNotification types.Bool   `tfsdk:"reminder_notification"`
+	ResolvedNotification types.Bool   `tfsdk:"resolved_notification"`
+	UnacknowledgeAfter   types.String `tfsdk:"unacknowledge_after"`
+}
+
+// escalationLevelModelV0 and the action models below are the nested models of
+// schema version 0. The corrigo and webhook configs didn't have the write-only
+// secrets yet.
+type escalationLevelModelV0 struct {
+	DisplayName   types.String                `tfsdk:"display_name"`
+	Actions       []notificationActionModelV0 `tfsdk:"actions"`
+	EscalateAfter types.String                `tfsdk:"escalate_after"`
+}
+
+type notificationActionModelV0 struct {
+	Type                 types.String               `tfsdk:"type"`
+	SMSConfig            *smsConfigModel            `tfsdk:"sms_config"`
+	EmailConfig          *emailConfigModel          `tfsdk:"email_config"`
+	CorrigoConfig        *corrigoConfigModelV0      `tfsdk:"corrigo_config"`
+	ServiceChannelConfig *serviceChannelConfigModel `tfsdk:"service_channel_config"`
+	WebhookConfig        *webhookConfigModelV0      `tfsdk:"webhook_config"`
+	PhoneCallConfig      *phoneCallConfigModel      `tfsdk:"phone_call_config"`
+	SignalTowerConfig    *signalTowerConfigModel    `tfsdk:"signal_tower_config"`
+}
+
+type corrigoConfigModelV0 struct {
+	AssetID              types.String `tfsdk:"asset_id"`
+	TaskID               types.String `tfsdk:"task_id"`
+	CustomerID           types.String `tfsdk:"customer_id"`
+	ClientID             types.String `tfsdk:"client_id"`
+	ClientSecret         types.String `tfsdk:"client_secret"`
+	CompanyName          types.String `tfsdk:"company_name"`
+	SubTypeID            types.String `tfsdk:"sub_type_id"`
+	ContactName          types.String `tfsdk:"contact_name"`
+	ContactAddress       types.String `tfsdk:"contact_address"`
+	WorkOrderDescription types.String `tfsdk:"work_order_description"`
+	StudioDashboardURL   types.String `tfsdk:"studio_dashboard_url"`
+}
+
+type webhookConfigModelV0 struct {
+	URL             types.String `tfsdk:"url"`
+	SignatureSecret types.String `tfsdk:"signature_secret"`
+	Headers         types.Map    `tfsdk:"headers"`
+}
+
+// notificationRuleSchemaV0 is schema version 0, the last schema with the
+// top-level actions. It only describes the types of the attributes, which is
+// all that is needed to read the state, and must never change.
+func notificationRuleSchemaV0() schema.Schema {
+	timeOfDay := schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"hour":   schema.Int32Attribute{Optional: true},
+			"minute": schema.Int32Attribute{Optional: true},
+		},
+	}
+	action := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{Optional: true},
+			"sms_config": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"recipients": schema.ListAttribute{Optional: true, ElementType: types.StringType},
+					"body":       schema.StringAttribute{Optional: true},
+				},
+			},
+			"email_config": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"recipients": schema.ListAttribute{Optional: true, ElementType: types.StringType},
+					"subject":    schema.StringAttribute{Optional: true},
+					"body":       schema.StringAttribute{Optional: true},
+				},
+			},
+			"corrigo_config": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"asset_id":               schema.StringAttribute{Optional: true},
+					"task_id":                schema.StringAttribute{Optional: true},
+					"customer_id":            schema.StringAttribute{Optional: true},
+					"client_id":              schema.StringAttribute{Optional: true},
+					"client_secret":          schema.StringAttribute{Optional: true, Sensitive: true},
+					"company_name":           schema.StringAttribute{Optional: true},
+					"sub_type_id":            schema.StringAttribute{Optional: true},
+					"contact_name":           schema.StringAttribute{Optional: true},
+					"contact_address":        schema.StringAttribute{Optional: true},
+					"work_order_description": schema.StringAttribute{Optional: true},
+					"studio_dashboard_url":   schema.StringAttribute{Optional: true},
+				},
+			},
+			"service_channel_config": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"store_id":     schema.StringAttribute{Optional: true},
+					"asset_tag_id": schema.StringAttribute{Optional: true},
+					"trade":        schema.StringAttribute{Optional: true},
+					"description":  schema.StringAttribute{Optional: true},
+				},
+			},
+			"webhook_config": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"url":              schema.StringAttribute{Optional: true},
+					"signature_secret": schema.StringAttribute{Optional: true, Sensitive: true},
+					"headers":          schema.MapAttribute{Optional: true, ElementType: types.StringType},
+				},
+			},
+			"phone_call_config": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"recipients":   schema.ListAttribute{Optional: true, ElementType: types.StringType},
+					"introduction": schema.StringAttribute{Optional: true},
+					"message":      schema.StringAttribute{Optional: true},
+				},
+			},
+			"signal_tower_config": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"cloud_connector_name": schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+	}
+
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":          schema.StringAttribute{Computed: true},
+			"enabled":       schema.BoolAttribute{Optional: true},
+			"display_name":  schema.StringAttribute{Optional: true},
+			"project_id":    schema.StringAttribute{Optional: true},
+			"devices":       schema.ListAttribute{Optional: true, ElementType: types.StringType},
+			"device_labels": schema.MapAttribute{Optional: true, ElementType: types.StringType},
+			"trigger": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{Optional: true},
+					"range": schema.SingleNestedAttribute{
+						Optional: true,
+						Attributes: map[string]schema.Attribute{
+							"lower": schema.Float64Attribute{Optional: true},
+							"upper": schema.Float64Attribute{Optional: true},
+							"type":  schema.StringAttribute{Optional: true},
+							"filter": schema.SingleNestedAttribute{
+								Optional: true,
+								Attributes: map[string]schema.Attribute{
+									"product_equivalent_temperature": schema.SingleNestedAttribute{
+										Optional:   true,
+										Attributes: map[string]schema.Attribute{},
+									},
+								},
+							},
+						},
+					},
+					"presence":      schema.StringAttribute{Optional: true},
+					"motion":        schema.StringAttribute{Optional: true},
+					"occupancy":     schema.StringAttribute{Optional: true},
+					"connection":    schema.StringAttribute{Optional: true},
+					"contact":       schema.StringAttribute{Optional: true},
+					"trigger_count": schema.Int32Attribute{Optional: true},
+				},
+			},
+			"actions": schema.ListNestedAttribute{Optional: true, NestedObject: action},
+			"escalation_levels": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"display_name":   schema.StringAttribute{Optional: true},
+						"actions":        schema.ListNestedAttribute{Optional: true, NestedObject: action},
+						"escalate_after": schema.StringAttribute{Optional: true},
+					},
+				},
+			},
+			"schedule": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"timezone": schema.StringAttribute{Optional: true},
+					"inverse":  schema.BoolAttribute{Optional: true},
+					"slots": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"day_of_week": schema.ListAttribute{Optional: true, ElementType: types.StringType},
+								"time_range": schema.ListNestedAttribute{
+									Optional: true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"start": timeOfDay,
+											"end":   timeOfDay,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"trigger_delay":         schema.StringAttribute{Optional: true},
+			"reminder_notification": schema.BoolAttribute{Optional: true},
+			"resolved_notification": schema.BoolAttribute{Optional: true},
+			"unacknowledge_after":   schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+// UpgradeState upgrades the state from earlier schema versions.
+func (r *notificationRuleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := notificationRuleSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior notificationRuleModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				state, diags := upgradeNotificationRuleV0(ctx, prior)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			},
+		},
+	}
+}
+
+// upgradeNotificationRuleV0 moves the top-level actions of a version 0 state into
+// a single escalation level, unless the rule already has escalation levels, which
+// take precedence over the actions in the API.
+func upgradeNotificationRuleV0(ctx context.Context, prior notificationRuleModelV0) (notificationRuleModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	state := notificationRuleModel{
+		Name:                 prior.Name,
+		Enabled:              prior.Enabled,
+		DisplayName:          prior.DisplayName,
+		ProjectID:            prior.ProjectID,
+		Devices:              prior.Devices,
+		DeviceLabels:         prior.DeviceLabels,
+		TriggerDelay:         prior.TriggerDelay,
+		ReminderNotification: prior.ReminderNotification,
+		ResolvedNotification: prior.ResolvedNotification,
+		UnacknowledgeAfter:   prior.UnacknowledgeAfter,
+		DeletionProtection:   types.BoolValue(false),
+	}
+	if !prior.Trigger.IsNull() {
+		state.Trigger = &triggerModel{}
+		diags.Append(prior.Trigger.As(ctx, state.Trigger, basetypes.ObjectAsOptions{})...)
+	}
+	if !prior.Schedule.IsNull() {
+		state.Schedule = &scheduleModel{}
+		diags.Append(prior.Schedule.As(ctx, state.Schedule, basetypes.ObjectAsOptions{})...)
+	}
+	var escalationLevels []escalationLevelModelV0
+	if !prior.EscalationLevels.IsNull() {
+		diags.Append(prior.EscalationLevels.ElementsAs(ctx, &escalationLevels, false)...)
+	}
+	for _, level := range escalationLevels {
+		state.EscalationLevels = append(state.EscalationLevels, escalationLevelModel{
+			DisplayName:   level.DisplayName,
+			Actions:       upgradeNotificationActionsV0(level.Actions),
+			EscalateAfter: level.EscalateAfter,
+		})
+	}
+
+	var actions []notificationActionModelV0
+	if !prior.Actions.IsNull() {
+		diags.Append(prior.Actions.ElementsAs(ctx, &actions, false)...)
+	}
+	if len(state.EscalationLevels) == 0 && len(actions) > 0 {
+		state.EscalationLevels = []escalationLevelModel{{
+			DisplayName:   types.StringValue(legacyEscalationLevelName),
+			Actions:       upgradeNotificationActionsV0(actions),
+			EscalateAfter: types.StringNull(),
+		}}
+	}
+	return state, diags
+}
+
+// upgradeNotificationActionsV0 converts version 0 actions to the current model,
+// leaving the write-only secrets unset.
+func upgradeNotificationActionsV0(prior []notificationActionModelV0) []notificationActionModel {
+	var actions []notificationActionModel
+	for _, action := range prior {
+		upgraded := notificationActionModel{
+			Type:                 action.Type,
+			SMSConfig:            action.SMSConfig,
+			EmailConfig:          action.EmailConfig,
+			ServiceChannelConfig: action.ServiceChannelConfig,
+			PhoneCallConfig:      action.PhoneCallConfig,
+			SignalTowerConfig:    action.SignalTowerConfig,
+		}
+		if config := action.CorrigoConfig; config != nil {
+			upgraded.CorrigoConfig = &corrigoConfigModel{
+				AssetID:               config.AssetID,
+				TaskID:                config.TaskID,
+				CustomerID:            config.CustomerID,
+				ClientID:              config.ClientID,
+				ClientSecret:          config.ClientSecret,
+				ClientSecretWO:        types.StringNull(),
+				ClientSecretWOVersion: types.Int64Null(),
+				CompanyName:           config.CompanyName,
+				SubTypeID:             config.SubTypeID,
+				ContactName:           config.ContactName,
+				ContactAddress:        config.ContactAddress,
+				WorkOrderDescription:  config.WorkOrderDescription,
+				StudioDashboardURL:    config.StudioDashboardURL,
+			}
+		}
+		if config := action.WebhookConfig; config != nil {
+			upgraded.WebhookConfig = &webhookConfigModel{
+				URL:                      config.URL,
+				SignatureSecret:          config.SignatureSecret,
+				SignatureSecretWO:        types.StringNull(),
+				SignatureSecretWOVersion: types.Int64Null(),
+				Headers:                  config.Headers,
+			}
+		}
+		actions = append(actions, upgraded)
+	}
+	return actions
+}
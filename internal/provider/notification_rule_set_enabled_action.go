@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &notificationRuleSetEnabledAction{}
+	_ action.ActionWithConfigure = &notificationRuleSetEnabledAction{}
+)
+
+// NewNotificationRuleSetEnabledAction is a helper function to simplify the provider implementation.
+func NewNotificationRuleSetEnabledAction() action.Action {
+	return &notificationRuleSetEnabledAction{}
+}
+
+// notificationRuleSetEnabledAction is the action implementation.
+type notificationRuleSetEnabledAction struct {
+	client *providerClient
+}
+
+// notificationRuleSetEnabledActionModel is the data model for the action.
+type notificationRuleSetEnabledActionModel struct {
+	NotificationRule types.String `tfsdk:"notification_rule"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata returns the action type name.
+func (a *notificationRuleSetEnabledAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_rule_set_enabled"
+}
+
+// Schema defines the schema for the action.
+func (a *notificationRuleSetEnabledAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enables or disables a notification rule, such as during maintenance. " +
+			"A `dt_notification_rule` managed by Terraform is set back to its configured `enabled` on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"notification_rule": schema.StringAttribute{
+				Required:    true,
+				Description: "The resource name of the notification rule on the form `projects/{project_id}/rules/{rule_id}`, such as `dt_notification_rule.freezer.name`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^projects/[^/]+/rules/[^/]+$`), "must be on the form projects/{project_id}/rules/{rule_id}"),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether the notification rule is enabled.",
+			},
+		},
+	}
+}
+
+// Invoke enables or disables the notification rule.
+func (a *notificationRuleSetEnabledAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config notificationRuleSetEnabledActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	verb := "Disabling"
+	if config.Enabled.ValueBool() {
+		verb = "Enabling"
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("%s %s", verb, config.NotificationRule.ValueString()),
+	})
+	if _, err := a.client.SetNotificationRuleEnabled(ctx, config.NotificationRule.ValueString(), config.Enabled.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Error updating notification rule", "Could not update notification rule: "+err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *notificationRuleSetEnabledAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"invalid provider data",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
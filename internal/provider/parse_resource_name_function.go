@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseResourceNameFunction{}
+
+// resourceNameAttributeTypes are the attributes of the object returned by parse_resource_name.
+var resourceNameAttributeTypes = map[string]attr.Type{
+	"collection":      types.StringType,
+	"id":              types.StringType,
+	"parent":          types.StringType,
+	"project_id":      types.StringType,
+	"organization_id": types.StringType,
+}
+
+// NewParseResourceNameFunction is a helper function to simplify the provider implementation.
+func NewParseResourceNameFunction() function.Function {
+	return &parseResourceNameFunction{}
+}
+
+// parseResourceNameFunction is the function implementation.
+type parseResourceNameFunction struct{}
+
+func (f *parseResourceNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_resource_name"
+}
+
+func (f *parseResourceNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a DT resource name",
+		MarkdownDescription: "Parses a DT resource name, such as `projects/{project_id}/devices/{device_id}`, into an object with the " +
+			"`collection` and `id` of the resource, the resource name of its `parent`, and the `project_id` and `organization_id` it belongs to. " +
+			"Attributes that don't apply to the resource name are null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The resource name to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: resourceNameAttributeTypes,
+		},
+	}
+}
+
+func (f *parseResourceNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	resourceName, err := dtapi.ParseName(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(resourceNameAttributeTypes, map[string]attr.Value{
+		"collection":      types.StringValue(resourceName.Collection),
+		"id":              types.StringValue(resourceName.ID),
+		"parent":          stringOrNull(resourceName.Parent),
+		"project_id":      stringOrNull(resourceName.ProjectID),
+		"organization_id": stringOrNull(resourceName.OrganizationID),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// stringOrNull returns a null string value for the empty string.
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
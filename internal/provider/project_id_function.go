@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &projectIDFunction{}
+
+// NewProjectIDFunction is a helper function to simplify the provider implementation.
+func NewProjectIDFunction() function.Function {
+	return &projectIDFunction{}
+}
+
+// projectIDFunction is the function implementation.
+type projectIDFunction struct{}
+
+func (f *projectIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "project_id"
+}
+
+func (f *projectIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Get the project ID from a DT resource name",
+		MarkdownDescription: "Returns the project ID of a project resource name, `projects/{project_id}`, " +
+			"or of any resource in a project, such as `projects/{project_id}/devices/{device_id}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The resource name of the project, or of a resource in the project.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *projectIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	resourceName, err := dtapi.ParseName(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if resourceName.ProjectID == "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("resource name %q does not belong to a project", name))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, resourceName.ProjectID))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &projectResource{}
+	_ list.ListResourceWithConfigure = &projectResource{}
+)
+
+// NewProjectListResource is a helper function to simplify the provider implementation.
+func NewProjectListResource() list.ListResource {
+	return &projectResource{}
+}
+
+type projectListModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	DisplayName    types.String `tfsdk:"display_name"`
+}
+
+// ListResourceConfigSchema defines the filters for listing projects.
+func (r *projectResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the projects in an organization, optionally filtered by display name.",
+		Attributes: map[string]listschema.Attribute{
+			"organization_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the organization to list projects in. Defaults to the provider's `default_organization`.",
+			},
+			"display_name": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list projects with this display name.",
+			},
+		},
+	}
+}
+
+// List lists the projects in an organization.
+func (r *projectResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	if r.client == nil {
+		resp.Results = list.ListResultsStreamDiagnostics(unconfiguredClientDiagnostics())
+		return
+	}
+
+	var config projectListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	organizationID, diags := listConfigDefault(config.OrganizationID, path.Root("organization_id"), "default_organization", r.client.DefaultOrganization)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	projects, err := r.client.ListProjects(ctx, "organizations/"+organizationID)
+	if err != nil {
+		diags.AddError("Error listing projects", "Could not list projects: "+err.Error())
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var matches []dtapi.Project
+	for _, project := range projects {
+		if matchesListFilter(config.DisplayName, nil, project.DisplayName, nil) {
+			matches = append(matches, project)
+		}
+	}
+
+	resp.Results = listResults(req, matches, func(project dtapi.Project) list.ListResult {
+		state, diags := projectToState(project)
+		return newListResult(ctx, req, project.DisplayName, state.Name, &projectIdentityModel{}, &state, diags)
+	})
+}
@@ -4,16 +4,17 @@ package provider
 
 import (
 	"context"
-	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
-	"github.com/disruptive-technologies/terraform-provider-dt/internal/dt"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
@@ -26,7 +27,9 @@ import (
 var (
 	_ resource.Resource                = &projectMemberRoleBindingsResource{}
 	_ resource.ResourceWithConfigure   = &projectMemberRoleBindingsResource{}
+	_ resource.ResourceWithModifyPlan  = &projectMemberRoleBindingsResource{}
 	_ resource.ResourceWithImportState = &projectMemberRoleBindingsResource{}
+	_ resource.ResourceWithIdentity    = &projectMemberRoleBindingsResource{}
 
 	validRoles = []string{
 		"roles/project.user",
@@ -41,17 +44,72 @@ func NewMemberResource() resource.Resource {
 
 // projectMemberRoleBindingsResource is the resource implementation.
 type projectMemberRoleBindingsResource struct {
-	client *dt.Client
+	client *providerClient
 }
 
+// projectMemberRoleBindingsReplaceAttributes are the attributes that require
+// replacement of the role bindings when they change.
+var projectMemberRoleBindingsReplaceAttributes = path.Paths{path.Root("projects"), path.Root("email"), path.Root("role")}
+
 // Metadata returns the resource type name.
 func (m *projectMemberRoleBindingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_project_member_role_bindings"
 }
 
 func (r *projectMemberRoleBindingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	// Import by the resource name, {organization_id}/{email}/{role}, or by the identity in an import block
+	importStateByNameOrIdentity(ctx, req, resp, &memberIdentityModel{}, r.resolveImportID)
+}
+
+// resolveImportID resolves an import ID on the form {organization_id}/{email}/{role} to the
+// name of the role binding. The role may be given with or without the roles/ prefix.
+func (r *projectMemberRoleBindingsResource) resolveImportID(ctx context.Context, id string) (string, error) {
+	parts, err := splitImportID(id, "organization_id", "email", "role")
+	if err != nil {
+		return "", err
+	}
+	organizationID, email, roleID := parts[0], strings.ToLower(parts[1]), strings.TrimPrefix(parts[2], "roles/")
+	memberships, err := r.client.ListProjectMembershipsByEmail(ctx, "organizations/"+organizationID, email)
+	if err != nil {
+		return "", err
+	}
+
+	// the role binding of a member covers all of its memberships with the role
+	candidates := make([]importCandidate, 0, len(memberships))
+	for _, membership := range memberships {
+		if !slices.Contains(membership.Roles, "roles/"+roleID) {
+			continue
+		}
+		memberID, err := membership.ID()
+		if err != nil {
+			return "", err
+		}
+		candidates = append(candidates, importCandidate{
+			name: dtapi.RoleBindingName{OrganizationID: organizationID, RoleID: roleID, MemberID: memberID}.String(),
+			key:  strings.ToLower(membership.Email),
+		})
+	}
+	return matchImportID("role binding", email, candidates)
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *projectMemberRoleBindingsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the organization.",
+			},
+			"role_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the role, such as `project.user`.",
+			},
+			"member_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the member.",
+			},
+		},
+	}
 }
 
 // Schema defines the schema for the resource.
@@ -80,8 +138,9 @@ func (m *projectMemberRoleBindingsResource) Schema(_ context.Context, _ resource
 				},
 			},
 			"organization": schema.StringAttribute{
-				Required:    true,
-				Description: "Resource name of the organization on the format `organizations/{organization_id}`.",
+				Optional:    true,
+				Computed:    true,
+				Description: "Resource name of the organization on the format `organizations/{organization_id}`. Defaults to the provider's `default_organization`.",
 			},
 			"projects": schema.SetAttribute{
 				Required:    true,
@@ -142,6 +201,32 @@ type membersResourceModel struct {
 	AccountType       types.String `tfsdk:"account_type"`
 }
 
+// memberIdentityModel is the identity of the resource.
+type memberIdentityModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	RoleID         types.String `tfsdk:"role_id"`
+	MemberID       types.String `tfsdk:"member_id"`
+}
+
+func (m *memberIdentityModel) name() string {
+	return dtapi.RoleBindingName{
+		OrganizationID: m.OrganizationID.ValueString(),
+		RoleID:         m.RoleID.ValueString(),
+		MemberID:       m.MemberID.ValueString(),
+	}.String()
+}
+
+func (m *memberIdentityModel) fromName(name string) error {
+	roleBinding, err := dtapi.ParseRoleBindingName(name)
+	if err != nil {
+		return err
+	}
+	m.OrganizationID = types.StringValue(roleBinding.OrganizationID)
+	m.RoleID = types.StringValue(roleBinding.RoleID)
+	m.MemberID = types.StringValue(roleBinding.MemberID)
+	return nil
+}
+
 // Create creates the resource and sets the initial state.
 func (m *projectMemberRoleBindingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve the data from the request.
@@ -177,6 +262,7 @@ func (m *projectMemberRoleBindingsResource) Create(ctx context.Context, req reso
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &memberIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -189,7 +275,7 @@ func (m *projectMemberRoleBindingsResource) Read(ctx context.Context, req resour
 		return
 	}
 
-	organizationID, roleID, memberID, err := decodeID(state.Name.ValueString())
+	roleBinding, err := dtapi.ParseRoleBindingName(state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error decoding project member ID",
@@ -197,11 +283,11 @@ func (m *projectMemberRoleBindingsResource) Read(ctx context.Context, req resour
 		)
 		return
 	}
-	organization := "organizations/" + organizationID
-	role := "roles/" + roleID
+	organization := "organizations/" + roleBinding.OrganizationID
+	role := "roles/" + roleBinding.RoleID
 
 	// get the project members for the organization and member ID
-	members, err := m.client.ListProjectMemberships(ctx, organization, role, memberID)
+	members, err := m.client.ListProjectMemberships(ctx, organization, role, roleBinding.MemberID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting project member",
@@ -223,6 +309,7 @@ func (m *projectMemberRoleBindingsResource) Read(ctx context.Context, req resour
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &memberIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -262,6 +349,7 @@ func (m *projectMemberRoleBindingsResource) Update(ctx context.Context, req reso
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &memberIdentityModel{}, newState.Name, &resp.Diagnostics)
 }
 
 // / Delete deletes the resource and removes the Terraform state on success.
@@ -290,6 +378,21 @@ func (m *projectMemberRoleBindingsResource) Delete(ctx context.Context, req reso
 	}
 }
 
+// ModifyPlan fills in the provider's default organization when organization is not set.
+// Any planned change fails when the provider is read only.
+func (m *projectMemberRoleBindingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkReadOnly(ctx, m.client, "dt_project_member_role_bindings", projectMemberRoleBindingsReplaceAttributes, req, resp)
+
+	if m.client == nil {
+		return
+	}
+	var organization string
+	if m.client.DefaultOrganization != "" {
+		organization = "organizations/" + m.client.DefaultOrganization
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization"), "default_organization", organization, false)
+}
+
 // Configure adds the provider configured client to the resource.
 func (m *projectMemberRoleBindingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -298,7 +401,7 @@ func (m *projectMemberRoleBindingsResource) Configure(ctx context.Context, req r
 		return
 	}
 
-	client, ok := req.ProviderData.(*dt.Client)
+	client, ok := req.ProviderData.(*providerClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -311,7 +414,7 @@ func (m *projectMemberRoleBindingsResource) Configure(ctx context.Context, req r
 	m.client = client
 }
 
-func stateToBatchDeleteProjectMembersRequest(ctx context.Context, plan membersResourceModel) (dt.BatchDeleteProjectMembersRequest, diag.Diagnostics) {
+func stateToBatchDeleteProjectMembersRequest(ctx context.Context, plan membersResourceModel) (dtapi.BatchDeleteProjectMembersRequest, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 	membersToDelete := make([]string, 0)
 	memberID := plan.MemberID.ValueString()
@@ -319,7 +422,7 @@ func stateToBatchDeleteProjectMembersRequest(ctx context.Context, plan membersRe
 	projects, d := expandStringSet(ctx, plan.Projects)
 	diags.Append(d...)
 	if diags.HasError() {
-		return dt.BatchDeleteProjectMembersRequest{}, diags
+		return dtapi.BatchDeleteProjectMembersRequest{}, diags
 	}
 	for _, project := range projects {
 		if project == "" {
@@ -327,42 +430,47 @@ func stateToBatchDeleteProjectMembersRequest(ctx context.Context, plan membersRe
 				"Error deleting project member",
 				"Project ID cannot be empty",
 			)
-			return dt.BatchDeleteProjectMembersRequest{}, diags
+			return dtapi.BatchDeleteProjectMembersRequest{}, diags
+		}
+		projectName, err := dtapi.ParseProjectName(project)
+		if err != nil {
+			diags.AddError("Error deleting project member", "Invalid project: "+err.Error())
+			return dtapi.BatchDeleteProjectMembersRequest{}, diags
 		}
-		membersToDelete = append(membersToDelete, fmt.Sprintf("%s/members/%s", project, memberID))
+		membersToDelete = append(membersToDelete, dtapi.MemberName{ProjectID: projectName.ProjectID, MemberID: memberID}.String())
 	}
-	return dt.BatchDeleteProjectMembersRequest{
+	return dtapi.BatchDeleteProjectMembersRequest{
 		Names: membersToDelete,
 	}, diags
 }
 
-func stateToBatchCreateProjectMemberRequest(ctx context.Context, plan membersResourceModel) (dt.BatchCreateProjectsMembersRequest, diag.Diagnostics) {
+func stateToBatchCreateProjectMemberRequest(ctx context.Context, plan membersResourceModel) (dtapi.BatchCreateProjectsMembersRequest, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	projects, d := expandStringSet(ctx, plan.Projects)
 	diags.Append(d...)
 	if diags.HasError() {
-		return dt.BatchCreateProjectsMembersRequest{}, diags
+		return dtapi.BatchCreateProjectsMembersRequest{}, diags
 	}
 
-	members := make([]dt.Members, 0, len(projects))
+	members := make([]dtapi.Members, 0, len(projects))
 
 	for _, project := range projects {
-		members = append(members, dt.Members{
+		members = append(members, dtapi.Members{
 			Project: project,
 			Email:   plan.Email.ValueString(),
 			Roles:   []string{plan.Role.ValueString()},
 		})
 	}
 
-	return dt.BatchCreateProjectsMembersRequest{
+	return dtapi.BatchCreateProjectsMembersRequest{
 		Members: members,
 	}, nil
 }
 
-func stateToMemberships(ctx context.Context, plan membersResourceModel) ([]dt.Membership, diag.Diagnostics) {
+func stateToMemberships(ctx context.Context, plan membersResourceModel) ([]dtapi.Membership, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	var memberships []dt.Membership
+	var memberships []dtapi.Membership
 
 	projects, d := expandStringSet(ctx, plan.Projects)
 	diags.Append(d...)
@@ -378,8 +486,13 @@ func stateToMemberships(ctx context.Context, plan membersResourceModel) ([]dt.Me
 			)
 			return nil, diags
 		}
-		memberships = append(memberships, dt.Membership{
-			Name:        fmt.Sprintf("%s/members/%s", project, plan.MemberID.ValueString()),
+		projectName, err := dtapi.ParseProjectName(project)
+		if err != nil {
+			diags.AddError("Error getting project member", "Invalid project: "+err.Error())
+			return nil, diags
+		}
+		memberships = append(memberships, dtapi.Membership{
+			Name:        dtapi.MemberName{ProjectID: projectName.ProjectID, MemberID: plan.MemberID.ValueString()}.String(),
 			DisplayName: plan.MemberDisplayName.ValueString(),
 			Email:       plan.Email.ValueString(),
 			Roles:       []string{plan.Role.ValueString()},
@@ -390,7 +503,7 @@ func stateToMemberships(ctx context.Context, plan membersResourceModel) ([]dt.Me
 	return memberships, diags
 }
 
-func membershipsToState(ctx context.Context, organization string, memberships []dt.Membership) (membersResourceModel, diag.Diagnostics) {
+func membershipsToState(ctx context.Context, organization string, memberships []dtapi.Membership) (membersResourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var err error
 	var projects []string
@@ -422,7 +535,7 @@ func membershipsToState(ctx context.Context, organization string, memberships []
 		}
 
 		if memberID == "" {
-			_, memberID, err = dt.ParseResourceName(membership.Name)
+			memberID, err = membership.ID()
 			if err != nil {
 				diags.AddError(
 					"Error parsing membership name",
@@ -464,7 +577,7 @@ func membershipsToState(ctx context.Context, organization string, memberships []
 			)
 			return membersResourceModel{}, diags
 		}
-		projects = append(projects, "projects/"+projectID)
+		projects = append(projects, dtapi.ProjectName{ProjectID: projectID}.String())
 	}
 
 	projectsSet, d := flattenStringSetToAttr(ctx, projects)
@@ -477,7 +590,7 @@ func membershipsToState(ctx context.Context, organization string, memberships []
 	roleID := strings.TrimPrefix(role, "roles/")
 
 	return membersResourceModel{
-		Name:              types.StringValue(fmt.Sprintf("organizations/%s/roles/%s/members/%s", organizationID, roleID, memberID)),
+		Name:              types.StringValue(dtapi.RoleBindingName{OrganizationID: organizationID, RoleID: roleID, MemberID: memberID}.String()),
 		MemberID:          types.StringValue(memberID),
 		MemberDisplayName: types.StringValue(displayName),
 		Projects:          projectsSet,
@@ -487,16 +600,3 @@ func membershipsToState(ctx context.Context, organization string, memberships []
 		AccountType:       types.StringValue(accountType),
 	}, diags
 }
-
-func decodeID(id string) (string, string, string, error) {
-	parts := strings.Split(id, "/")
-	if len(parts) != 6 {
-		return "", "", "", fmt.Errorf("invalid ID format: %s", id)
-	}
-
-	if parts[0] != "organizations" || parts[2] != "roles" || parts[4] != "members" {
-		return "", "", "", fmt.Errorf("invalid ID format: %s", id)
-	}
-
-	return parts[1], parts[3], parts[5], nil
-}
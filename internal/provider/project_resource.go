@@ -6,24 +6,29 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/disruptive-technologies/terraform-provider-dt/internal/dt"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &projectResource{}
-	_ resource.ResourceWithConfigure   = &projectResource{}
-	_ resource.ResourceWithImportState = &projectResource{}
+	_ resource.Resource                   = &projectResource{}
+	_ resource.ResourceWithConfigure      = &projectResource{}
+	_ resource.ResourceWithModifyPlan     = &projectResource{}
+	_ resource.ResourceWithValidateConfig = &projectResource{}
+	_ resource.ResourceWithImportState    = &projectResource{}
+	_ resource.ResourceWithIdentity       = &projectResource{}
 )
 
 // NewProjectResource is a helper function to simplify the provider implementation.
@@ -33,7 +38,7 @@ func NewProjectResource() resource.Resource {
 
 // projectResource is the resource implementation.
 type projectResource struct {
-	client *dt.Client
+	client *providerClient
 }
 
 // Metadata returns the resource type name.
@@ -42,8 +47,38 @@ func (r *projectResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	// Import by the resource name, {organization_id}/{display_name}, or by the identity in an import block
+	importStateByNameOrIdentity(ctx, req, resp, &projectIdentityModel{}, r.resolveImportID)
+}
+
+// resolveImportID resolves an import ID on the form {organization_id}/{display_name} to the name of the project.
+func (r *projectResource) resolveImportID(ctx context.Context, id string) (string, error) {
+	parts, err := splitImportID(id, "organization_id", "display_name")
+	if err != nil {
+		return "", err
+	}
+	projects, err := r.client.ListProjects(ctx, "organizations/"+parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	candidates := make([]importCandidate, 0, len(projects))
+	for _, project := range projects {
+		candidates = append(candidates, importCandidate{name: project.Name, key: project.DisplayName})
+	}
+	return matchImportID("project", parts[1], candidates)
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *projectResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"project_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the project.",
+			},
+		},
+	}
 }
 
 // Schema defines the schema for the resource.
@@ -76,8 +111,9 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"organization": schema.StringAttribute{
-				Required:    true,
-				Description: "The reource name of the organization that the project belongs to. on the form `organizations/{organization_id}`.",
+				Optional:    true,
+				Computed:    true,
+				Description: "The reource name of the organization that the project belongs to. on the form `organizations/{organization_id}`. Defaults to the provider's `default_organization`.",
 			},
 			"organization_display_name": schema.StringAttribute{
 				Computed:    true,
@@ -100,6 +136,7 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					int32planmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("project"),
 			"location": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "The location of the project.",
@@ -134,9 +171,28 @@ type projectResourceModel struct {
 	OrganizationDisplayName types.String                  `tfsdk:"organization_display_name"`
 	SensorCount             types.Int32                   `tfsdk:"sensor_count"`
 	CloudConnectorCount     types.Int32                   `tfsdk:"cloud_connector_count"`
+	DeletionProtection      types.Bool                    `tfsdk:"deletion_protection"`
 	Location                *projectLocationResourceModel `tfsdk:"location"`
 }
 
+// projectIdentityModel is the identity of the resource.
+type projectIdentityModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+}
+
+func (m *projectIdentityModel) name() string {
+	return dtapi.ProjectName{ProjectID: m.ProjectID.ValueString()}.String()
+}
+
+func (m *projectIdentityModel) fromName(name string) error {
+	projectName, err := dtapi.ParseProjectName(name)
+	if err != nil {
+		return err
+	}
+	m.ProjectID = types.StringValue(projectName.ProjectID)
+	return nil
+}
+
 type projectLocationResourceModel struct {
 	Latitude     types.Float64 `tfsdk:"latitude"`
 	Longitude    types.Float64 `tfsdk:"longitude"`
@@ -162,18 +218,20 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	plan, diags := projectToState(project)
+	state, diags := projectToState(project)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.DeletionProtection = deletionProtectionFromPrior(plan.DeletionProtection)
 
 	// Set the Terraform state.
-	dias = resp.State.Set(ctx, &plan)
+	dias = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(dias...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &projectIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -193,11 +251,13 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	prior := state
 	state, diags = projectToState(project)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.DeletionProtection = deletionProtectionFromPrior(prior.DeletionProtection)
 
 	// set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -205,6 +265,7 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &projectIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -238,6 +299,7 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setIdentity(ctx, resp.Identity, &projectIdentityModel{}, state.Name, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -250,6 +312,11 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, "dt_project", "destroyed")
+		return
+	}
+
 	// delete the project
 	err := r.client.DeleteProject(ctx, state.Name.ValueString())
 	if err != nil {
@@ -258,6 +325,36 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ValidateConfig checks the display name against the provider policy.
+func (r *projectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if r.client == nil || r.client.Policy == nil {
+		return
+	}
+	validateDisplayNamePolicy(ctx, r.client.Policy, req.Config, &resp.Diagnostics)
+}
+
+// ModifyPlan fills in the provider's default organization when organization is not set,
+// and warns when a project that still has sensors or cloud connectors is destroyed.
+// Any planned change fails when the provider is read only, and destroying a protected
+// project fails.
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkReadOnly(ctx, r.client, "dt_project", nil, req, resp)
+	defer checkDeletionProtection(ctx, "dt_project", nil, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		warnProjectNotEmpty(ctx, req.State, &resp.Diagnostics)
+		return
+	}
+	if r.client == nil {
+		return
+	}
+	var organization string
+	if r.client.DefaultOrganization != "" {
+		organization = "organizations/" + r.client.DefaultOrganization
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization"), "default_organization", organization, false)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *projectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -266,12 +363,12 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*dt.Client)
+	client, ok := req.ProviderData.(*providerClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dt.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -280,7 +377,27 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
-func projectToState(project dt.Project) (projectResourceModel, diag.Diagnostics) {
+// warnProjectNotEmpty warns when the project in the state still has sensors or
+// cloud connectors, as they can't be recovered once the project is destroyed.
+func warnProjectNotEmpty(ctx context.Context, state tfsdk.State, diags *diag.Diagnostics) {
+	var project projectResourceModel
+	diags.Append(state.Get(ctx, &project)...)
+	if diags.HasError() {
+		return
+	}
+	sensors, cloudConnectors := project.SensorCount.ValueInt32(), project.CloudConnectorCount.ValueInt32()
+	if sensors == 0 && cloudConnectors == 0 {
+		return
+	}
+	diags.AddWarning(
+		"Project is not empty",
+		fmt.Sprintf("The project %q (%s) would be destroyed, but it still has %d sensors and %d cloud connectors. "+
+			"Move them to another project first, or set `deletion_protection = true` to keep the project from being destroyed.",
+			project.DisplayName.ValueString(), project.Name.ValueString(), sensors, cloudConnectors),
+	)
+}
+
+func projectToState(project dtapi.Project) (projectResourceModel, diag.Diagnostics) {
 	id, err := project.ID()
 	if err != nil {
 		diags := diag.NewErrorDiagnostic("ID", "failed to get project ID")
@@ -307,6 +424,7 @@ func projectToState(project dt.Project) (projectResourceModel, diag.Diagnostics)
 		OrganizationDisplayName: types.StringValue(project.OrganizationDisplayName),
 		SensorCount:             types.Int32Value(int32(project.SensorCount)),
 		CloudConnectorCount:     types.Int32Value(int32(project.CloudConnectorCount)),
+		DeletionProtection:      types.BoolValue(false),
 		Location: &projectLocationResourceModel{
 			Latitude:     latitude,
 			Longitude:    longitude,
@@ -315,8 +433,8 @@ func projectToState(project dt.Project) (projectResourceModel, diag.Diagnostics)
 	}, nil
 }
 
-func stateToProject(state projectResourceModel) dt.Project {
-	project := dt.Project{
+func stateToProject(state projectResourceModel) dtapi.Project {
+	project := dtapi.Project{
 		Name:                    state.Name.ValueString(),
 		DisplayName:             state.DisplayName.ValueString(),
 		Inventory:               state.Inventory.ValueBool(),
@@ -326,7 +444,7 @@ func stateToProject(state projectResourceModel) dt.Project {
 		CloudConnectorCount:     int(state.CloudConnectorCount.ValueInt32()),
 	}
 	if state.Location != nil {
-		project.Location = dt.Location{
+		project.Location = dtapi.Location{
 			Latitude:     state.Location.Latitude.ValueFloat64Pointer(),
 			Longitude:    state.Location.Longitude.ValueFloat64Pointer(),
 			TimeLocation: state.Location.TimeLocation.ValueString(),
@@ -335,14 +453,14 @@ func stateToProject(state projectResourceModel) dt.Project {
 	return project
 }
 
-func stateToUpdateProjectRequest(state projectResourceModel) dt.EditableProject {
-	updateRequest := dt.EditableProject{
+func stateToUpdateProjectRequest(state projectResourceModel) dtapi.EditableProject {
+	updateRequest := dtapi.EditableProject{
 		Name:         state.Name.ValueString(),
 		DisplayName:  state.DisplayName.ValueString(),
 		Organization: state.Organization.ValueString(),
 	}
 	if state.Location != nil {
-		updateRequest.Location = dt.Location{
+		updateRequest.Location = dtapi.Location{
 			Latitude:     state.Location.Latitude.ValueFloat64Pointer(),
 			Longitude:    state.Location.Longitude.ValueFloat64Pointer(),
 			TimeLocation: state.Location.TimeLocation.ValueString(),
@@ -351,7 +469,7 @@ func stateToUpdateProjectRequest(state projectResourceModel) dt.EditableProject
 	return updateRequest
 }
 
-func updateProjectState(project dt.EditableProject, state *projectResourceModel) {
+func updateProjectState(project dtapi.EditableProject, state *projectResourceModel) {
 	state.Name = types.StringValue(project.Name)
 	state.DisplayName = types.StringValue(project.DisplayName)
 	state.Organization = types.StringValue(project.Organization)
@@ -3,8 +3,18 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -75,7 +85,7 @@ func TestAccSafeProjectResource(t *testing.T) {
 			{
 				Config: providerConfig + readTestFile(t, "../../testdata/project/empty_location.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("dt_project.test", "display_name", "Empty Location Project"),
+					resource.TestCheckResourceAttr("dt_project.test", "display_name", "Acceptance Test empty location project"),
 					resource.TestCheckResourceAttr("dt_project.test", "location.time_location", "UTC"),
 					resource.TestCheckResourceAttr("dt_project.test", "inventory", "false"),
 				),
@@ -83,3 +93,95 @@ func TestAccSafeProjectResource(t *testing.T) {
 		},
 	})
 }
+
+func TestWarnProjectNotEmpty(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	(&projectResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	tests := map[string]struct {
+		sensors         int32
+		cloudConnectors int32
+		wantWarning     bool
+	}{
+		"empty":            {},
+		"sensors":          {sensors: 3, wantWarning: true},
+		"cloud connectors": {cloudConnectors: 1, wantWarning: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := state.Set(ctx, &projectResourceModel{
+				Name:                types.StringValue("projects/c8n5cmpsd1f4ma0p5jt0"),
+				DisplayName:         types.StringValue("Warehouse"),
+				SensorCount:         types.Int32Value(tt.sensors),
+				CloudConnectorCount: types.Int32Value(tt.cloudConnectors),
+			})
+			if diags.HasError() {
+				t.Fatalf("failed to set state: %v", diags)
+			}
+
+			diags = diag.Diagnostics{}
+			warnProjectNotEmpty(ctx, state, &diags)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tt.wantWarning {
+				t.Errorf("expected warning %t, got %v", tt.wantWarning, diags)
+			}
+		})
+	}
+}
+
+func TestProjectList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		case r.URL.Path != "/v2/projects":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Query().Get("organization") == "organizations/o1":
+			fmt.Fprint(w, `{"projects":[
+				{"name":"projects/p1","displayName":"Warehouse","organization":"organizations/o1"},
+				{"name":"projects/p2","displayName":"Office","organization":"organizations/o1"}
+			]}`)
+		default:
+			fmt.Fprint(w, `{"projects":[{"name":"projects/p3","displayName":"Lab","organization":"organizations/o2"}]}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := map[string]struct {
+		config map[string]tftypes.Value
+		limit  int64
+		want   []string
+	}{
+		"default organization": {want: []string{"Warehouse", "Office"}},
+		"organization": {
+			config: map[string]tftypes.Value{"organization_id": tftypes.NewValue(tftypes.String, "o2")},
+			want:   []string{"Lab"},
+		},
+		"display name": {
+			config: map[string]tftypes.Value{"display_name": tftypes.NewValue(tftypes.String, "Office")},
+			want:   []string{"Office"},
+		},
+		"limit": {limit: 1, want: []string{"Warehouse"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := listResource(t, "dt_project", testServerProviderConfig(t, server.URL), tt.config, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
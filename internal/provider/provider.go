@@ -5,16 +5,26 @@ package provider
 
 import (
 	"context"
-	"os"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
 
-	"github.com/disruptive-technologies/terraform-provider-dt/internal/dt"
-	"github.com/disruptive-technologies/terraform-provider-dt/internal/dt/oidc"
+	"github.com/disruptive-technologies/terraform-provider-dt/internal/policy"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi/oidc"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -22,6 +32,9 @@ import (
 // Ensure The provider satisfies various provider interfaces.
 var _ provider.Provider = &DTProvider{}
 var _ provider.ProviderWithFunctions = &DTProvider{}
+var _ provider.ProviderWithEphemeralResources = &DTProvider{}
+var _ provider.ProviderWithListResources = &DTProvider{}
+var _ provider.ProviderWithActions = &DTProvider{}
 
 // DTProvider defines the provider implementation.
 type DTProvider struct {
@@ -44,6 +57,15 @@ func (p *DTProvider) Metadata(ctx context.Context, req provider.MetadataRequest,
 func (p *DTProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Description: "The DT environment to use preset endpoints for: `production` or `custom`. " +
+					"The `url`, `emulator_url` and `token_endpoint` settings override the preset. With `custom` every endpoint must be set. " +
+					"Can also be set with the `DT_ENVIRONMENT` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dtapi.EnvironmentNames()...),
+				},
+			},
 			"url": schema.StringAttribute{
 				Description: "The URL of the API server.",
 				// Can use either environment variables or configuration, therefore optional: true
@@ -65,29 +87,146 @@ func (p *DTProvider) Schema(ctx context.Context, req provider.SchemaRequest, res
 				// Can use either environment variables or configuration, therefore optional: true
 				Optional: true,
 			},
+			"private_key": schema.StringAttribute{
+				Description: "PEM encoded RSA or EC private key of the service account. When set, the token request is signed with RS256 or ES256 " +
+					"using this key instead of the key secret. Can also be set with the `DT_API_PRIVATE_KEY` environment variable. Conflicts with `private_key_file`.",
+				Sensitive: true,
+				Optional:  true,
+			},
+			"private_key_file": schema.StringAttribute{
+				Description: "Path to a PEM encoded RSA or EC private key of the service account. Can also be set with the `DT_API_PRIVATE_KEY_FILE` environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key")),
+				},
+			},
 			"token_endpoint": schema.StringAttribute{
 				Description: "The token endpoint for the OIDC provider.",
 				// Can use either environment variables or configuration, therefore optional: true
 				Optional: true,
 			},
+			"issuer": schema.StringAttribute{
+				Description: "The issuer URL of the OIDC provider. When `token_endpoint` is not set, it is discovered from the issuer's " +
+					"`/.well-known/openid-configuration` document. Can also be set with the `DT_OIDC_ISSUER` environment variable.",
+				Optional: true,
+			},
 			"email": schema.StringAttribute{
 				Description: "The email address used to authenticate with the OIDC provider.",
 				// Can use either environment variables or configuration, therefore optional: true
 				Optional: true,
 			},
+			"profile": schema.StringAttribute{
+				Description: "The named profile in the DT configuration file to read settings from. Can also be set with the `DT_PROFILE` environment variable. " +
+					"Defaults to the `default` profile if present. Provider attributes and environment variables take precedence over the profile.",
+				Optional: true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "Path to the DT configuration file holding the named profiles. Can also be set with the `DT_CONFIG_FILE` environment variable. Defaults to `~/.config/dt/config`.",
+				Optional:    true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "Skip fetching a token and calling the DT API to validate the credentials when the provider is configured. " +
+					"Useful for offline `terraform validate`. Can also be set with the `DT_SKIP_CREDENTIALS_VALIDATION` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Refuse every request that would change something in DT, and fail the plan of any create, update or delete. " +
+					"Useful for drift detection with broadly scoped credentials. Can also be set with the `DT_READ_ONLY` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"token_cache": schema.BoolAttribute{
+				Description: "Cache access tokens on disk so they are shared between provider processes, for example between plan and apply. " +
+					"Tokens are reused until shortly before they expire. Can also be set with the `DT_TOKEN_CACHE` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"default_organization": schema.StringAttribute{
+				Description: "The organization used by resources that don't set `organization`, as an ID or on the form `organizations/{organization_id}`. " +
+					"Can also be set with the `DT_DEFAULT_ORGANIZATION` environment variable.",
+				Optional: true,
+			},
+			"default_project": schema.StringAttribute{
+				Description: "The project used by resources that don't set `project_id` or `project`, as an ID or on the form `projects/{project_id}`. " +
+					"Can also be set with the `DT_DEFAULT_PROJECT` environment variable.",
+				Optional: true,
+			},
+			"default_labels": schema.MapAttribute{
+				Description: "Labels added to every labelled resource managed by the provider, such as `dt_emulator`. " +
+					"Labels set on the resource take precedence. The effective labels are exposed in the resource's `labels_all` attribute.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"token_cache_dir": schema.StringAttribute{
+				Description: "Directory for the on-disk token cache. Can also be set with the `DT_TOKEN_CACHE_DIR` environment variable. Defaults to `dt/tokens` in the user cache directory.",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"policy": schema.SingleNestedBlock{
+				Description: "Naming and labelling conventions checked when resources are validated. " +
+					"Applies to the display names of `dt_project`, `dt_emulator`, `dt_data_connector` and `dt_notification_rule`, the labels of `dt_emulator`, " +
+					"the label keys of `dt_data_connector` and the `device_labels` of `dt_notification_rule`.",
+				Attributes: map[string]schema.Attribute{
+					"display_name_pattern": schema.StringAttribute{
+						Description: "Regular expression that display names must match, for example `^team-a-`.",
+						Optional:    true,
+					},
+					"allowed_label_keys": schema.SetAttribute{
+						Description: "The label keys that may be used. Any label key is allowed when not set.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"label_value_patterns": schema.MapAttribute{
+						Description: "Regular expressions that the values of the labels with the same key must match.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"severity": schema.StringAttribute{
+						Description: "Whether policy violations are reported as an `error` or a `warning`. Defaults to `error`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(policy.SeverityError, policy.SeverityWarning),
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 // hashicupsProviderModel maps provider schema data to a Go type.
 type dtProviderModel struct {
+	Environment types.String `tfsdk:"environment"`
 	URL         types.String `tfsdk:"url"`
 	EmulatorURL types.String `tfsdk:"emulator_url"`
 	// OIDC
-	ClientID      types.String `tfsdk:"key_id"`
-	ClientSecret  types.String `tfsdk:"key_secret"`
-	TokenEndpoint types.String `tfsdk:"token_endpoint"`
-	Email         types.String `tfsdk:"email"`
+	ClientID       types.String `tfsdk:"key_id"`
+	ClientSecret   types.String `tfsdk:"key_secret"`
+	TokenEndpoint  types.String `tfsdk:"token_endpoint"`
+	Issuer         types.String `tfsdk:"issuer"`
+	Email          types.String `tfsdk:"email"`
+	PrivateKey     types.String `tfsdk:"private_key"`
+	PrivateKeyFile types.String `tfsdk:"private_key_file"`
+	// Profiles
+	Profile                   types.String `tfsdk:"profile"`
+	ConfigFile                types.String `tfsdk:"config_file"`
+	SkipCredentialsValidation types.Bool   `tfsdk:"skip_credentials_validation"`
+	ReadOnly                  types.Bool   `tfsdk:"read_only"`
+	// Token cache
+	TokenCache    types.Bool   `tfsdk:"token_cache"`
+	TokenCacheDir types.String `tfsdk:"token_cache_dir"`
+	// Resource defaults
+	DefaultOrganization types.String   `tfsdk:"default_organization"`
+	DefaultProject      types.String   `tfsdk:"default_project"`
+	DefaultLabels       types.Map      `tfsdk:"default_labels"`
+	Policy              *dtPolicyModel `tfsdk:"policy"`
+}
+
+// dtPolicyModel describes the policy block of the provider.
+type dtPolicyModel struct {
+	DisplayNamePattern types.String `tfsdk:"display_name_pattern"`
+	AllowedLabelKeys   types.Set    `tfsdk:"allowed_label_keys"`
+	LabelValuePatterns types.Map    `tfsdk:"label_value_patterns"`
+	Severity           types.String `tfsdk:"severity"`
 }
 
 func (p *DTProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -99,74 +238,22 @@ func (p *DTProvider) Configure(ctx context.Context, req provider.ConfigureReques
 		return
 	}
 
-	url := os.Getenv("DT_API_URL")
-	if url == "" {
-		if config.URL.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("url"),
-				"URL must be set",
-				"The URL of the dt api server must be set",
-			)
-		} else {
-			url = config.URL.ValueString()
-		}
-	}
-	emulatorURL := os.Getenv("DT_EMULATOR_URL")
-	if emulatorURL == "" {
-		if config.EmulatorURL.IsUnknown() {
-			emulatorURL = "https://emulator.disruptive-technologies.com/"
-		} else {
-			emulatorURL = config.EmulatorURL.ValueString()
-		}
-	}
-
-	keyID := os.Getenv("DT_API_KEY_ID")
-	if keyID == "" {
-		if config.ClientID.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("key_id"),
-				"Key ID must be set",
-				"The key ID to authenticate with must be set",
-			)
-		} else {
-			keyID = config.ClientID.ValueString()
-		}
-	}
+	settings, diags := newConfigResolver().resolve(config)
+	resp.Diagnostics.Append(diags...)
 
-	keySecret := os.Getenv("DT_API_KEY_SECRET")
-	if keySecret == "" {
-		if config.ClientSecret.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("key_secret"),
-				"key secret must be set",
-				"The secret to authenticate with must be set",
-			)
-		} else {
-			keySecret = config.ClientSecret.ValueString()
-		}
-	}
-	tokenEndpoint := os.Getenv("DT_OIDC_TOKEN_ENDPOINT")
-	if tokenEndpoint == "" {
-		if config.TokenEndpoint.IsUnknown() {
+	tokenEndpoint := settings.TokenEndpoint
+	if tokenEndpoint == "" && settings.Issuer != "" && !diags.HasError() {
+		// Discover the token endpoint now, so a bad issuer is reported here
+		// rather than on the first API call.
+		metadata, err := oidc.Discover(ctx, settings.Issuer)
+		if err != nil {
 			resp.Diagnostics.AddAttributeError(
-				path.Root("token_endpoint"),
-				"Token endpoint must be set",
-				"The token endpoint for the OIDC provider must be set",
+				path.Root("issuer"),
+				"Failed to discover OIDC provider metadata",
+				err.Error(),
 			)
 		} else {
-			tokenEndpoint = config.TokenEndpoint.ValueString()
-		}
-	}
-	email := os.Getenv("DT_OIDC_EMAIL")
-	if email == "" {
-		if config.Email.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("email"),
-				"Email must be set",
-				"The email address used to authenticate with the OIDC provider must be set",
-			)
-		} else {
-			email = config.Email.ValueString()
+			tokenEndpoint = metadata.TokenEndpoint
 		}
 	}
 
@@ -178,27 +265,106 @@ func (p *DTProvider) Configure(ctx context.Context, req provider.ConfigureReques
 		return
 	}
 
-	ctx = tflog.SetField(ctx, "url", url)
-	ctx = tflog.SetField(ctx, "key_id", keyID)
+	ctx = tflog.SetField(ctx, "environment", settings.Environment)
+	ctx = tflog.SetField(ctx, "url", settings.URL)
+	ctx = tflog.SetField(ctx, "key_id", settings.KeyID)
 	ctx = tflog.SetField(ctx, "token_endpoint", tokenEndpoint)
-	ctx = tflog.SetField(ctx, "email", email)
+	ctx = tflog.SetField(ctx, "email", settings.Email)
+	ctx = tflog.SetField(ctx, "read_only", settings.ReadOnly)
 	tflog.Debug(ctx, "provider parameters")
 
-	client := dt.NewClient(dt.Config{
-		URL:         url,
-		EmulatorURL: emulatorURL,
-		Version:     p.version,
-		Oidc: oidc.Config{
-			TokenEndpoint: tokenEndpoint,
-			ClientID:      keyID,
-			ClientSecret:  keySecret,
-			Email:         email,
-		},
-	})
+	client, err := newProviderClient(settings, tokenEndpoint, p.version)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create DT client", err.Error())
+		return
+	}
+
+	// Fail early with a clear diagnostic if the credentials don't work,
+	// instead of on whichever resource happens to be read first.
+	if !settings.SkipCredentialsValidation {
+		if err := client.ValidateCredentials(ctx); err != nil {
+			resp.Diagnostics.Append(credentialsDiagnostic(err))
+			return
+		}
+	}
 
 	// make the client available to the rest of the provider
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ListResourceData = client
+	resp.ActionData = client
+}
+
+// newProviderClient returns the DT client for the resolved provider settings.
+func newProviderClient(settings providerSettings, tokenEndpoint string, version string) (*providerClient, error) {
+	opts := []dtapi.Option{
+		dtapi.WithURL(settings.URL),
+		dtapi.WithEmulatorURL(settings.EmulatorURL),
+		dtapi.WithUserAgent(fmt.Sprintf("TerraformProviderDT/%s(%s)", version, runtime.Version())),
+		dtapi.WithReadOnly(settings.ReadOnly),
+		dtapi.WithTokenCacheDir(settings.TokenCacheDir),
+	}
+	if tokenEndpoint != "" {
+		opts = append(opts, dtapi.WithTokenEndpoint(tokenEndpoint))
+	} else if settings.Issuer != "" {
+		opts = append(opts, dtapi.WithIssuer(settings.Issuer))
+	}
+	if settings.PrivateKey != nil {
+		opts = append(opts, dtapi.WithPrivateKey(settings.KeyID, settings.PrivateKey, settings.Email))
+	} else {
+		opts = append(opts, dtapi.WithServiceAccountKey(settings.KeyID, settings.KeySecret, settings.Email))
+	}
+
+	client, err := dtapi.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &providerClient{
+		Client:              client,
+		DefaultOrganization: settings.DefaultOrganization,
+		DefaultProject:      settings.DefaultProject,
+		DefaultLabels:       settings.DefaultLabels,
+		Policy:              settings.Policy,
+	}, nil
+}
+
+// credentialsDiagnostic turns a credentials validation error into a
+// diagnostic pointing at the setting that is most likely wrong.
+func credentialsDiagnostic(err error) diag.Diagnostic {
+	var oidcErr *oidc.HTTPError
+	var tokenErr *dtapi.TokenError
+	var apiErr *dtapi.HTTPError
+	switch {
+	case errors.As(err, &oidcErr):
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("key_id"),
+			"Invalid service account credentials",
+			"The OIDC provider rejected the service account credentials. Check the `key_id` (DT_API_KEY_ID), "+
+				"`key_secret` (DT_API_KEY_SECRET) or `private_key` (DT_API_PRIVATE_KEY), and `email` (DT_OIDC_EMAIL) settings.\n\n"+err.Error(),
+		)
+	case errors.As(err, &tokenErr):
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("token_endpoint"),
+			"Failed to get an access token",
+			"Could not get an access token from the OIDC provider. Check the `token_endpoint` (DT_OIDC_TOKEN_ENDPOINT) "+
+				"or `issuer` (DT_OIDC_ISSUER) settings.\n\n"+err.Error(),
+		)
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden):
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("key_id"),
+			"Service account not authorized",
+			"The DT API rejected the access token of the service account. Check that the `key_id` (DT_API_KEY_ID) "+
+				"belongs to an active service account, and that `url` (DT_API_URL) and `token_endpoint` belong to the same environment.\n\n"+err.Error(),
+		)
+	default:
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("url"),
+			"Failed to reach the DT API",
+			"Could not validate the credentials against the DT API. Check the `url` (DT_API_URL) setting, "+
+				"or set `skip_credentials_validation` to configure the provider offline.\n\n"+err.Error(),
+		)
+	}
 }
 
 // Resources defines the resources implemented in the provider.
@@ -219,8 +385,40 @@ func (p *DTProvider) DataSources(ctx context.Context) []func() datasource.DataSo
 	}
 }
 
+func (p *DTProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
+	}
+}
+
+func (p *DTProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewProjectListResource,
+		NewDataConnectorListResource,
+		NewNotificationRuleListResource,
+		NewEmulatorListResource,
+		NewDeviceListResource,
+	}
+}
+
+func (p *DTProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewEmulatorPublishEventAction,
+		NewDataConnectorSyncAction,
+		NewNotificationRuleSetEnabledAction,
+	}
+}
+
 func (p *DTProvider) Functions(ctx context.Context) []func() function.Function {
-	return nil
+	return []func() function.Function{
+		NewParseResourceNameFunction,
+		NewProjectIDFunction,
+		NewDeviceNameFunction,
+		NewRuleNameFunction,
+		NewScheduleFunction,
+		NewConvertTemperatureFunction,
+		NewTemperatureRangeFunction,
+	}
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -0,0 +1,522 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/internal/policy"
+	"github.com/disruptive-technologies/terraform-provider-dt/internal/profile"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi/oidc"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultEmulatorURL is used when neither emulator_url nor an environment is set.
+const defaultEmulatorURL = "https://emulator.disruptive-technologies.com/"
+
+// providerSettings are the provider settings after resolving the provider
+// attributes, environment variables, profile and defaults.
+type providerSettings struct {
+	Environment               string
+	URL                       string
+	EmulatorURL               string
+	KeyID                     string
+	KeySecret                 string
+	PrivateKey                []byte
+	TokenEndpoint             string
+	Issuer                    string
+	Email                     string
+	SkipCredentialsValidation bool
+	TokenCacheDir             string
+	DefaultOrganization       string
+	DefaultProject            string
+	DefaultLabels             map[string]string
+	ReadOnly                  bool
+	Policy                    *policy.Policy
+}
+
+// configResolver resolves the provider settings. Each setting is taken from
+// the first source that sets it, in the following order:
+//
+//  1. The provider attribute.
+//  2. The environment variable.
+//  3. The selected profile in the DT configuration file.
+//  4. The preset of the selected environment, for the endpoints.
+//  5. The default value, if the setting has one.
+type configResolver struct {
+	// getenv looks up environment variables, os.Getenv outside of tests.
+	getenv func(string) string
+}
+
+func newConfigResolver() configResolver {
+	return configResolver{getenv: os.Getenv}
+}
+
+// resolve resolves all provider settings. Every missing or invalid setting is
+// reported in the returned diagnostics, not just the first one.
+func (r configResolver) resolve(config dtProviderModel) (providerSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var settings providerSettings
+
+	diags.Append(checkUnknown(config)...)
+	if diags.HasError() {
+		return settings, diags
+	}
+
+	prof, err := r.loadProfile(config)
+	if err != nil {
+		diags.AddAttributeError(path.Root("profile"), "Failed to load profile", err.Error())
+	}
+
+	settings.Environment = r.lookup(config.Environment, "DT_ENVIRONMENT", prof.Environment)
+	preset, err := environmentPreset(settings.Environment)
+	if err != nil {
+		diags.AddAttributeError(path.Root("environment"), "Invalid environment", err.Error())
+	}
+
+	settings.URL = r.lookup(config.URL, "DT_API_URL", prof.URL)
+	if settings.URL == "" {
+		settings.URL = preset.URL
+	}
+	if settings.URL == "" {
+		diags.AddAttributeError(
+			path.Root("url"),
+			"URL must be set",
+			"The URL of the dt api server must be set with the `url` attribute or the DT_API_URL environment variable",
+		)
+	} else {
+		diags.Append(validateURL(path.Root("url"), settings.URL)...)
+	}
+
+	settings.EmulatorURL = r.lookup(config.EmulatorURL, "DT_EMULATOR_URL", prof.EmulatorURL)
+	if settings.EmulatorURL == "" {
+		settings.EmulatorURL = preset.EmulatorURL
+	}
+	if settings.EmulatorURL == "" && settings.Environment != dtapi.EnvironmentCustom {
+		settings.EmulatorURL = defaultEmulatorURL
+	}
+	if settings.EmulatorURL == "" {
+		diags.AddAttributeError(
+			path.Root("emulator_url"),
+			"Emulator URL must be set",
+			"The URL of the emulator server must be set with the `emulator_url` attribute or the DT_EMULATOR_URL environment variable "+
+				"when the environment is `custom`",
+		)
+	} else {
+		diags.Append(validateURL(path.Root("emulator_url"), settings.EmulatorURL)...)
+	}
+
+	settings.KeyID = r.lookup(config.ClientID, "DT_API_KEY_ID", prof.KeyID)
+	if settings.KeyID == "" {
+		diags.AddAttributeError(
+			path.Root("key_id"),
+			"Key ID must be set",
+			"The key ID to authenticate with must be set with the `key_id` attribute or the DT_API_KEY_ID environment variable",
+		)
+	}
+
+	settings.PrivateKey, err = r.loadPrivateKey(config, prof)
+	if err != nil {
+		diags.AddAttributeError(path.Root("private_key"), "Invalid private key", err.Error())
+	}
+
+	settings.KeySecret = r.lookup(config.ClientSecret, "DT_API_KEY_SECRET", prof.KeySecret)
+	if settings.KeySecret == "" && settings.PrivateKey == nil && err == nil {
+		diags.AddAttributeError(
+			path.Root("key_secret"),
+			"key secret must be set",
+			"The secret to authenticate with must be set with the `key_secret` attribute or the DT_API_KEY_SECRET environment variable, unless a private key is used",
+		)
+	}
+
+	settings.TokenEndpoint = r.lookup(config.TokenEndpoint, "DT_OIDC_TOKEN_ENDPOINT", prof.TokenEndpoint)
+	settings.Issuer = r.lookup(config.Issuer, "DT_OIDC_ISSUER", prof.Issuer)
+	if settings.TokenEndpoint == "" && settings.Issuer == "" {
+		settings.TokenEndpoint = preset.TokenEndpoint
+	}
+	if settings.TokenEndpoint == "" && settings.Issuer == "" {
+		diags.AddAttributeError(
+			path.Root("token_endpoint"),
+			"Token endpoint must be set",
+			"The token endpoint for the OIDC provider must be set with the `token_endpoint` attribute or the DT_OIDC_TOKEN_ENDPOINT environment variable, "+
+				"or discovered by setting the issuer",
+		)
+	}
+	if settings.TokenEndpoint != "" {
+		diags.Append(validateURL(path.Root("token_endpoint"), settings.TokenEndpoint)...)
+	}
+	if settings.Issuer != "" {
+		diags.Append(validateURL(path.Root("issuer"), settings.Issuer)...)
+	}
+
+	diags.Append(mixedEnvironmentsDiagnostics(settings)...)
+
+	settings.Email = r.lookup(config.Email, "DT_OIDC_EMAIL", prof.Email)
+	if settings.Email == "" {
+		diags.AddAttributeError(
+			path.Root("email"),
+			"Email must be set",
+			"The email address used to authenticate with the OIDC provider must be set with the `email` attribute or the DT_OIDC_EMAIL environment variable",
+		)
+	}
+
+	settings.SkipCredentialsValidation, err = r.lookupBool(config.SkipCredentialsValidation, "DT_SKIP_CREDENTIALS_VALIDATION")
+	if err != nil {
+		diags.AddAttributeError(path.Root("skip_credentials_validation"), "Invalid skip_credentials_validation", err.Error())
+	}
+
+	settings.ReadOnly, err = r.lookupBool(config.ReadOnly, "DT_READ_ONLY")
+	if err != nil {
+		diags.AddAttributeError(path.Root("read_only"), "Invalid read_only", err.Error())
+	}
+
+	settings.TokenCacheDir, err = r.tokenCacheDir(config)
+	if err != nil {
+		diags.AddAttributeError(path.Root("token_cache"), "Invalid token cache configuration", err.Error())
+	}
+
+	settings.DefaultOrganization, err = resourceID(r.lookup(config.DefaultOrganization, "DT_DEFAULT_ORGANIZATION", ""), "organizations")
+	if err != nil {
+		diags.AddAttributeError(path.Root("default_organization"), "Invalid default_organization", err.Error())
+	}
+	settings.DefaultProject, err = resourceID(r.lookup(config.DefaultProject, "DT_DEFAULT_PROJECT", ""), "projects")
+	if err != nil {
+		diags.AddAttributeError(path.Root("default_project"), "Invalid default_project", err.Error())
+	}
+
+	for key, value := range config.DefaultLabels.Elements() {
+		if settings.DefaultLabels == nil {
+			settings.DefaultLabels = make(map[string]string)
+		}
+		label, ok := value.(types.String)
+		if !ok || label.IsNull() {
+			diags.AddAttributeError(path.Root("default_labels").AtMapKey(key), "Invalid default label", fmt.Sprintf("The value of label %q must be a string", key))
+			continue
+		}
+		settings.DefaultLabels[key] = label.ValueString()
+	}
+	for _, systemLabel := range []string{"name", "virtual-sensor"} {
+		if _, ok := settings.DefaultLabels[systemLabel]; ok {
+			diags.AddAttributeError(
+				path.Root("default_labels").AtMapKey(systemLabel),
+				"Invalid default label",
+				fmt.Sprintf("The %q label is managed by the provider and can't be used as a default label", systemLabel),
+			)
+		}
+	}
+
+	var policyDiags diag.Diagnostics
+	settings.Policy, policyDiags = resolvePolicy(config.Policy)
+	diags.Append(policyDiags...)
+	for key, value := range settings.DefaultLabels {
+		if err := settings.Policy.CheckLabel(key, value); err != nil {
+			addPolicyViolation(&diags, settings.Policy, path.Root("default_labels").AtMapKey(key), err)
+		}
+	}
+
+	return settings, diags
+}
+
+// lookup resolves a single string setting: the provider attribute, then the
+// environment variable and finally the value from the selected profile.
+func (r configResolver) lookup(attribute types.String, envKey string, profileValue string) string {
+	if value := attribute.ValueString(); value != "" {
+		return value
+	}
+	if value := r.getenv(envKey); value != "" {
+		return value
+	}
+	return profileValue
+}
+
+// lookupBool resolves a single boolean setting: the provider attribute, then
+// the environment variable. Unset booleans are false.
+func (r configResolver) lookupBool(attribute types.Bool, envKey string) (bool, error) {
+	if !attribute.IsNull() {
+		return attribute.ValueBool(), nil
+	}
+	if value := r.getenv(envKey); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid value for %s: %w", envKey, err)
+		}
+		return parsed, nil
+	}
+	return false, nil
+}
+
+// loadProfile loads the profile selected by the provider configuration.
+// A missing configuration file is only an error when a profile was selected
+// explicitly, otherwise the default profile is used if it exists.
+func (r configResolver) loadProfile(config dtProviderModel) (profile.Profile, error) {
+	configFile := r.lookup(config.ConfigFile, "DT_CONFIG_FILE", "")
+	if configFile == "" {
+		defaultPath, err := profile.DefaultPath()
+		if err != nil {
+			return profile.Profile{}, err
+		}
+		configFile = defaultPath
+	}
+
+	name := r.lookup(config.Profile, "DT_PROFILE", "")
+	if name == "" {
+		prof, err := profile.Load(configFile, profile.DefaultProfile)
+		if err != nil && !profile.IsNotExist(err) && !profile.IsNotFound(err) {
+			return profile.Profile{}, err
+		}
+		return prof, nil
+	}
+
+	return profile.Load(configFile, name)
+}
+
+// loadPrivateKey returns the PEM encoded private key used to sign the token
+// request, or nil when the key secret should be used instead.
+func (r configResolver) loadPrivateKey(config dtProviderModel, prof profile.Profile) ([]byte, error) {
+	var privateKey []byte
+	if key := r.lookup(config.PrivateKey, "DT_API_PRIVATE_KEY", ""); key != "" {
+		privateKey = []byte(key)
+	} else if file := r.lookup(config.PrivateKeyFile, "DT_API_PRIVATE_KEY_FILE", prof.PrivateKeyFile); file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key file: %w", err)
+		}
+		privateKey = content
+	} else {
+		return nil, nil
+	}
+
+	if err := oidc.ValidatePrivateKey(privateKey); err != nil {
+		return nil, err
+	}
+	return privateKey, nil
+}
+
+// tokenCacheDir returns the directory for the on-disk token cache, or an empty
+// string when the cache is disabled.
+func (r configResolver) tokenCacheDir(config dtProviderModel) (string, error) {
+	enabled, err := r.lookupBool(config.TokenCache, "DT_TOKEN_CACHE")
+	if err != nil || !enabled {
+		return "", err
+	}
+
+	if dir := r.lookup(config.TokenCacheDir, "DT_TOKEN_CACHE_DIR", ""); dir != "" {
+		return dir, nil
+	}
+	return oidc.DefaultCacheDir()
+}
+
+// resourceID returns the ID from value, which is either an ID or a resource
+// name on the form {collection}/{id}.
+func resourceID(value string, collection string) (string, error) {
+	id := strings.TrimPrefix(value, collection+"/")
+	if strings.Contains(id, "/") {
+		return "", fmt.Errorf("%q must be an ID or a resource name on the form %s/{id}", value, collection)
+	}
+	return id, nil
+}
+
+// resolvePolicy compiles the policy block, or returns nil when there is none.
+func resolvePolicy(config *dtPolicyModel) (*policy.Policy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if config == nil {
+		return nil, diags
+	}
+
+	policyPath := path.Root("policy")
+	if config.DisplayNamePattern.IsUnknown() || config.AllowedLabelKeys.IsUnknown() ||
+		config.LabelValuePatterns.IsUnknown() || config.Severity.IsUnknown() {
+		diags.AddAttributeError(policyPath, "Unknown policy", "The provider cannot be configured because the policy is not known until apply.")
+		return nil, diags
+	}
+
+	resolved := &policy.Policy{Severity: policy.SeverityError}
+	if !config.Severity.IsNull() {
+		resolved.Severity = config.Severity.ValueString()
+	}
+
+	if !config.DisplayNamePattern.IsNull() {
+		pattern, err := regexp.Compile(config.DisplayNamePattern.ValueString())
+		if err != nil {
+			diags.AddAttributeError(policyPath.AtName("display_name_pattern"), "Invalid display name pattern", err.Error())
+		}
+		resolved.DisplayNamePattern = pattern
+	}
+
+	if !config.AllowedLabelKeys.IsNull() {
+		resolved.AllowedLabelKeys = []string{}
+		for _, element := range config.AllowedLabelKeys.Elements() {
+			if key, ok := element.(types.String); ok && !key.IsNull() && !key.IsUnknown() {
+				resolved.AllowedLabelKeys = append(resolved.AllowedLabelKeys, key.ValueString())
+			}
+		}
+		sort.Strings(resolved.AllowedLabelKeys)
+	}
+
+	for key, element := range config.LabelValuePatterns.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		pattern, err := regexp.Compile(value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(policyPath.AtName("label_value_patterns").AtMapKey(key), "Invalid label value pattern", err.Error())
+			continue
+		}
+		if resolved.LabelValuePatterns == nil {
+			resolved.LabelValuePatterns = make(map[string]*regexp.Regexp)
+		}
+		resolved.LabelValuePatterns[key] = pattern
+	}
+
+	return resolved, diags
+}
+
+// environmentPreset returns the preset endpoints for the named environment.
+// The custom environment, and no environment at all, have no preset.
+func environmentPreset(name string) (dtapi.Environment, error) {
+	if name == "" || name == dtapi.EnvironmentCustom {
+		return dtapi.Environment{}, nil
+	}
+	preset, ok := dtapi.Environments[name]
+	if !ok {
+		return dtapi.Environment{}, fmt.Errorf("unknown environment %q, expected one of: %s", name, strings.Join(dtapi.EnvironmentNames(), ", "))
+	}
+	return preset, nil
+}
+
+// mixedEnvironmentsDiagnostics warns when configured endpoints don't belong to
+// the selected environment, or to production when no environment is selected
+// but some endpoints are production endpoints. Endpoints on localhost, such as
+// a local emulator, and the custom environment are not considered.
+func mixedEnvironmentsDiagnostics(settings providerSettings) diag.Diagnostics {
+	var diags diag.Diagnostics
+	endpoints := []struct {
+		name  string
+		value string
+	}{
+		{"url", settings.URL},
+		{"emulator_url", settings.EmulatorURL},
+		{"token_endpoint", settings.TokenEndpoint},
+		{"issuer", settings.Issuer},
+	}
+
+	environment, ok := dtapi.Environments[settings.Environment]
+	if !ok {
+		if settings.Environment != "" {
+			return diags
+		}
+		environment = dtapi.Environments[dtapi.EnvironmentProduction]
+		implied := false
+		for _, endpoint := range endpoints {
+			implied = implied || environment.Contains(endpoint.value)
+		}
+		if !implied {
+			return diags
+		}
+	}
+
+	var outside []string
+	for _, endpoint := range endpoints {
+		if endpoint.value == "" || environment.Contains(endpoint.value) || isLocalhostURL(endpoint.value) {
+			continue
+		}
+		outside = append(outside, fmt.Sprintf("%s: %s", endpoint.name, endpoint.value))
+	}
+	if len(outside) == 0 {
+		return diags
+	}
+	diags.AddWarning(
+		"Endpoints from different DT environments",
+		fmt.Sprintf("The other endpoints belong to the %s environment, but these don't, which usually means settings of two "+
+			"environments were mixed up:\n\n%s", environment.Name, strings.Join(outside, "\n")),
+	)
+	return diags
+}
+
+// checkUnknown reports provider attributes that are not known until apply.
+// The provider has to be configured during plan, so these can't be used.
+func checkUnknown(config dtProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	attributes := []struct {
+		name  string
+		value interface{ IsUnknown() bool }
+	}{
+		{"environment", config.Environment},
+		{"url", config.URL},
+		{"emulator_url", config.EmulatorURL},
+		{"key_id", config.ClientID},
+		{"key_secret", config.ClientSecret},
+		{"token_endpoint", config.TokenEndpoint},
+		{"issuer", config.Issuer},
+		{"email", config.Email},
+		{"private_key", config.PrivateKey},
+		{"private_key_file", config.PrivateKeyFile},
+		{"profile", config.Profile},
+		{"config_file", config.ConfigFile},
+		{"skip_credentials_validation", config.SkipCredentialsValidation},
+		{"read_only", config.ReadOnly},
+		{"token_cache", config.TokenCache},
+		{"token_cache_dir", config.TokenCacheDir},
+		{"default_organization", config.DefaultOrganization},
+		{"default_project", config.DefaultProject},
+		{"default_labels", config.DefaultLabels},
+	}
+	for _, attribute := range attributes {
+		if attribute.value.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root(attribute.name),
+				fmt.Sprintf("Unknown %s", attribute.name),
+				fmt.Sprintf("The provider cannot be configured because %s is not known until apply. "+
+					"Set it to a static value, or use the environment variable instead.", attribute.name),
+			)
+		}
+	}
+	return diags
+}
+
+// validateURL checks that value is an absolute https URL. Plain http is only
+// accepted for localhost, such as for a local emulator or test server.
+func validateURL(attribute path.Path, value string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	parsed, err := url.Parse(value)
+	if err != nil {
+		diags.AddAttributeError(attribute, "Invalid URL", fmt.Sprintf("%q is not a valid URL: %s", value, err))
+		return diags
+	}
+	if parsed.Host == "" || (parsed.Scheme != "https" && (parsed.Scheme != "http" || !isLocalhost(parsed.Hostname()))) {
+		diags.AddAttributeError(
+			attribute,
+			"Invalid URL",
+			fmt.Sprintf("%q must be an absolute URL using https, for example https://api.disruptive-technologies.com. "+
+				"Only localhost may use http.", value),
+		)
+	}
+	return diags
+}
+
+// isLocalhostURL reports whether the host of rawURL is localhost or a loopback
+// address.
+func isLocalhostURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && isLocalhost(parsed.Hostname())
+}
+
+// isLocalhost reports whether host is localhost or a loopback address.
+func isLocalhost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,636 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testProfileConfig = `
+[default]
+url            = https://api.default.example.com
+token_endpoint = https://identity.default.example.com/oauth2/token
+
+[staging]
+url            = https://api.staging.example.com
+emulator_url   = https://emulator.staging.example.com
+token_endpoint = https://identity.staging.example.com/oauth2/token
+key_id         = staging-key
+key_secret     = staging-secret
+email          = staging@example.com
+`
+
+// testResolver returns a configResolver that reads environment variables from
+// env instead of the process environment. DT_CONFIG_FILE defaults to a file
+// that does not exist, so the user's own configuration is never read.
+func testResolver(t *testing.T, env map[string]string) configResolver {
+	t.Helper()
+	if _, ok := env["DT_CONFIG_FILE"]; !ok {
+		env["DT_CONFIG_FILE"] = filepath.Join(t.TempDir(), "does-not-exist")
+	}
+	return configResolver{getenv: func(key string) string { return env[key] }}
+}
+
+func writeTestProfileConfig(t *testing.T) string {
+	t.Helper()
+	configFile := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(configFile, []byte(testProfileConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return configFile
+}
+
+// errorAttributes returns the sorted attribute paths of the error diagnostics.
+func errorAttributes(diags diag.Diagnostics) []string {
+	var attributes []string
+	for _, d := range diags.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			attributes = append(attributes, withPath.Path().String())
+		}
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+func TestConfigResolverLookup(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		attribute types.String
+		env       string
+		profile   string
+		want      string
+	}{
+		"attribute wins": {
+			attribute: types.StringValue("from-attribute"),
+			env:       "from-env",
+			profile:   "from-profile",
+			want:      "from-attribute",
+		},
+		"environment variable wins over profile": {
+			attribute: types.StringNull(),
+			env:       "from-env",
+			profile:   "from-profile",
+			want:      "from-env",
+		},
+		"profile is used when nothing else is set": {
+			attribute: types.StringNull(),
+			profile:   "from-profile",
+			want:      "from-profile",
+		},
+		"empty attribute is treated as unset": {
+			attribute: types.StringValue(""),
+			env:       "from-env",
+			want:      "from-env",
+		},
+		"empty when nothing is set": {
+			attribute: types.StringNull(),
+			want:      "",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := testResolver(t, map[string]string{"DT_TEST_SETTING": tt.env})
+			if got := r.lookup(tt.attribute, "DT_TEST_SETTING", tt.profile); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfigResolverLookupBool(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		attribute types.Bool
+		env       string
+		want      bool
+		wantErr   bool
+	}{
+		"attribute false wins over environment variable": {
+			attribute: types.BoolValue(false),
+			env:       "true",
+			want:      false,
+		},
+		"attribute true": {
+			attribute: types.BoolValue(true),
+			want:      true,
+		},
+		"environment variable": {
+			attribute: types.BoolNull(),
+			env:       "1",
+			want:      true,
+		},
+		"invalid environment variable": {
+			attribute: types.BoolNull(),
+			env:       "yes please",
+			wantErr:   true,
+		},
+		"false when nothing is set": {
+			attribute: types.BoolNull(),
+			want:      false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := testResolver(t, map[string]string{"DT_TEST_SETTING": tt.env})
+			got, err := r.lookupBool(tt.attribute, "DT_TEST_SETTING")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfigResolverLoadProfile(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeTestProfileConfig(t)
+
+	tests := map[string]struct {
+		config  dtProviderModel
+		env     map[string]string
+		wantURL string
+		wantErr bool
+	}{
+		"default profile when none is selected": {
+			config:  dtProviderModel{ConfigFile: types.StringValue(configFile)},
+			wantURL: "https://api.default.example.com",
+		},
+		"profile attribute": {
+			config:  dtProviderModel{ConfigFile: types.StringValue(configFile), Profile: types.StringValue("staging")},
+			wantURL: "https://api.staging.example.com",
+		},
+		"DT_PROFILE": {
+			config:  dtProviderModel{ConfigFile: types.StringValue(configFile)},
+			env:     map[string]string{"DT_PROFILE": "staging"},
+			wantURL: "https://api.staging.example.com",
+		},
+		"profile attribute wins over DT_PROFILE": {
+			config:  dtProviderModel{ConfigFile: types.StringValue(configFile), Profile: types.StringValue("default")},
+			env:     map[string]string{"DT_PROFILE": "staging"},
+			wantURL: "https://api.default.example.com",
+		},
+		"DT_CONFIG_FILE": {
+			env:     map[string]string{"DT_CONFIG_FILE": configFile, "DT_PROFILE": "staging"},
+			wantURL: "https://api.staging.example.com",
+		},
+		"selected profile must exist": {
+			config:  dtProviderModel{ConfigFile: types.StringValue(configFile), Profile: types.StringValue("production")},
+			wantErr: true,
+		},
+		"missing config file without a selected profile": {
+			config: dtProviderModel{ConfigFile: types.StringValue(filepath.Join(t.TempDir(), "does-not-exist"))},
+		},
+		"missing config file with a selected profile": {
+			config:  dtProviderModel{ConfigFile: types.StringValue(filepath.Join(t.TempDir(), "does-not-exist")), Profile: types.StringValue("staging")},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			env := map[string]string{}
+			for key, value := range tt.env {
+				env[key] = value
+			}
+			prof, err := testResolver(t, env).loadProfile(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if prof.URL != tt.wantURL {
+				t.Errorf("expected url %q, got %q", tt.wantURL, prof.URL)
+			}
+		})
+	}
+}
+
+func TestConfigResolverResolve(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeTestProfileConfig(t)
+
+	complete := func() dtProviderModel {
+		return dtProviderModel{
+			URL:           types.StringValue("https://api.example.com"),
+			ClientID:      types.StringValue("key"),
+			ClientSecret:  types.StringValue("secret"),
+			TokenEndpoint: types.StringValue("https://identity.example.com/oauth2/token"),
+			Email:         types.StringValue("service-account@example.com"),
+		}
+	}
+
+	tests := map[string]struct {
+		config     dtProviderModel
+		env        map[string]string
+		want       providerSettings
+		wantErrors []string
+	}{
+		"attributes only": {
+			config: complete(),
+			want: providerSettings{
+				URL:           "https://api.example.com",
+				EmulatorURL:   defaultEmulatorURL,
+				KeyID:         "key",
+				KeySecret:     "secret",
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+				Email:         "service-account@example.com",
+			},
+		},
+		"environment variables only": {
+			env: map[string]string{
+				"DT_API_URL":                     "https://api.env.example.com",
+				"DT_EMULATOR_URL":                "https://emulator.env.example.com",
+				"DT_API_KEY_ID":                  "env-key",
+				"DT_API_KEY_SECRET":              "env-secret",
+				"DT_OIDC_TOKEN_ENDPOINT":         "https://identity.env.example.com/oauth2/token",
+				"DT_OIDC_EMAIL":                  "env@example.com",
+				"DT_SKIP_CREDENTIALS_VALIDATION": "true",
+			},
+			want: providerSettings{
+				URL:                       "https://api.env.example.com",
+				EmulatorURL:               "https://emulator.env.example.com",
+				KeyID:                     "env-key",
+				KeySecret:                 "env-secret",
+				TokenEndpoint:             "https://identity.env.example.com/oauth2/token",
+				Email:                     "env@example.com",
+				SkipCredentialsValidation: true,
+			},
+		},
+		"attributes win over environment variables and profile": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.ConfigFile = types.StringValue(configFile)
+				config.Profile = types.StringValue("staging")
+				return config
+			}(),
+			env: map[string]string{
+				"DT_API_URL":    "https://api.env.example.com",
+				"DT_API_KEY_ID": "env-key",
+			},
+			want: providerSettings{
+				URL:           "https://api.example.com",
+				EmulatorURL:   "https://emulator.staging.example.com",
+				KeyID:         "key",
+				KeySecret:     "secret",
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+				Email:         "service-account@example.com",
+			},
+		},
+		"environment variables win over profile": {
+			config: dtProviderModel{
+				ConfigFile: types.StringValue(configFile),
+				Profile:    types.StringValue("staging"),
+			},
+			env: map[string]string{
+				"DT_API_URL":    "https://api.env.example.com",
+				"DT_OIDC_EMAIL": "env@example.com",
+			},
+			want: providerSettings{
+				URL:           "https://api.env.example.com",
+				EmulatorURL:   "https://emulator.staging.example.com",
+				KeyID:         "staging-key",
+				KeySecret:     "staging-secret",
+				TokenEndpoint: "https://identity.staging.example.com/oauth2/token",
+				Email:         "env@example.com",
+			},
+		},
+		"issuer instead of token endpoint": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.TokenEndpoint = types.StringNull()
+				config.Issuer = types.StringValue("https://identity.example.com")
+				return config
+			}(),
+			want: providerSettings{
+				URL:         "https://api.example.com",
+				EmulatorURL: defaultEmulatorURL,
+				KeyID:       "key",
+				KeySecret:   "secret",
+				Issuer:      "https://identity.example.com",
+				Email:       "service-account@example.com",
+			},
+		},
+		"environment preset": {
+			config: dtProviderModel{
+				Environment:  types.StringValue("production"),
+				ClientID:     types.StringValue("key"),
+				ClientSecret: types.StringValue("secret"),
+				Email:        types.StringValue("service-account@example.com"),
+			},
+			want: providerSettings{
+				Environment:   "production",
+				URL:           "https://api.disruptive-technologies.com",
+				EmulatorURL:   "https://emulator.disruptive-technologies.com",
+				KeyID:         "key",
+				KeySecret:     "secret",
+				TokenEndpoint: "https://identity.disruptive-technologies.com/oauth2/token",
+				Email:         "service-account@example.com",
+			},
+		},
+		"endpoints override the environment preset": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.Environment = types.StringValue("production")
+				return config
+			}(),
+			env: map[string]string{"DT_EMULATOR_URL": "https://emulator.example.com"},
+			want: providerSettings{
+				Environment:   "production",
+				URL:           "https://api.example.com",
+				EmulatorURL:   "https://emulator.example.com",
+				KeyID:         "key",
+				KeySecret:     "secret",
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+				Email:         "service-account@example.com",
+			},
+		},
+		"issuer wins over the preset token endpoint": {
+			config: dtProviderModel{
+				Environment:  types.StringValue("production"),
+				Issuer:       types.StringValue("https://identity.example.com"),
+				ClientID:     types.StringValue("key"),
+				ClientSecret: types.StringValue("secret"),
+				Email:        types.StringValue("service-account@example.com"),
+			},
+			want: providerSettings{
+				Environment: "production",
+				URL:         "https://api.disruptive-technologies.com",
+				EmulatorURL: "https://emulator.disruptive-technologies.com",
+				KeyID:       "key",
+				KeySecret:   "secret",
+				Issuer:      "https://identity.example.com",
+				Email:       "service-account@example.com",
+			},
+		},
+		"custom environment requires every endpoint": {
+			config: dtProviderModel{
+				Environment:  types.StringValue("custom"),
+				ClientID:     types.StringValue("key"),
+				ClientSecret: types.StringValue("secret"),
+				Email:        types.StringValue("service-account@example.com"),
+			},
+			wantErrors: []string{"emulator_url", "token_endpoint", "url"},
+		},
+		"unknown environment": {
+			config:     complete(),
+			env:        map[string]string{"DT_ENVIRONMENT": "qa"},
+			wantErrors: []string{"environment"},
+		},
+		"resource defaults": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.DefaultOrganization = types.StringValue("organizations/org-id")
+				return config
+			}(),
+			env: map[string]string{"DT_DEFAULT_PROJECT": "project-id"},
+			want: providerSettings{
+				URL:                 "https://api.example.com",
+				EmulatorURL:         defaultEmulatorURL,
+				KeyID:               "key",
+				KeySecret:           "secret",
+				TokenEndpoint:       "https://identity.example.com/oauth2/token",
+				Email:               "service-account@example.com",
+				DefaultOrganization: "org-id",
+				DefaultProject:      "project-id",
+			},
+		},
+		"invalid default project": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.DefaultProject = types.StringValue("projects/project-id/devices/device-id")
+				return config
+			}(),
+			wantErrors: []string{"default_project"},
+		},
+		"system label as default label": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.DefaultLabels = types.MapValueMust(types.StringType, map[string]attr.Value{
+					"managed-by":     types.StringValue("terraform"),
+					"virtual-sensor": types.StringValue(""),
+				})
+				return config
+			}(),
+			wantErrors: []string{`default_labels["virtual-sensor"]`},
+		},
+		"invalid policy pattern": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.Policy = &dtPolicyModel{
+					DisplayNamePattern: types.StringValue("^team-a-("),
+					AllowedLabelKeys:   types.SetNull(types.StringType),
+					LabelValuePatterns: types.MapNull(types.StringType),
+				}
+				return config
+			}(),
+			wantErrors: []string{"policy.display_name_pattern"},
+		},
+		"default labels violating the policy": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.DefaultLabels = types.MapValueMust(types.StringType, map[string]attr.Value{
+					"owner": types.StringValue("team-a"),
+				})
+				config.Policy = &dtPolicyModel{
+					AllowedLabelKeys:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("managed-by")}),
+					LabelValuePatterns: types.MapNull(types.StringType),
+				}
+				return config
+			}(),
+			wantErrors: []string{`default_labels["owner"]`},
+		},
+		"every missing setting is reported": {
+			wantErrors: []string{"email", "key_id", "key_secret", "token_endpoint", "url"},
+		},
+		"settings are still resolved after a profile error": {
+			config: dtProviderModel{
+				URL:        types.StringValue("https://api.example.com"),
+				ConfigFile: types.StringValue(configFile),
+				Profile:    types.StringValue("production"),
+			},
+			wantErrors: []string{"email", "key_id", "key_secret", "profile", "token_endpoint"},
+		},
+		"invalid urls": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.URL = types.StringValue("api.example.com")
+				config.EmulatorURL = types.StringValue("ftp://emulator.example.com")
+				config.TokenEndpoint = types.StringValue("https://")
+				config.Issuer = types.StringValue("://identity.example.com")
+				return config
+			}(),
+			wantErrors: []string{"emulator_url", "issuer", "token_endpoint", "url"},
+		},
+		"http urls": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.URL = types.StringValue("http://api.example.com")
+				config.EmulatorURL = types.StringValue("http://localhost:8080")
+				config.TokenEndpoint = types.StringValue("http://127.0.0.1:9000/oauth2/token")
+				return config
+			}(),
+			wantErrors: []string{"url"},
+		},
+		"unknown values": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.URL = types.StringUnknown()
+				config.TokenCache = types.BoolUnknown()
+				return config
+			}(),
+			wantErrors: []string{"token_cache", "url"},
+		},
+		"invalid boolean environment variable": {
+			config:     complete(),
+			env:        map[string]string{"DT_TOKEN_CACHE": "sometimes"},
+			wantErrors: []string{"token_cache"},
+		},
+		"token cache directory": {
+			config: func() dtProviderModel {
+				config := complete()
+				config.TokenCache = types.BoolValue(true)
+				return config
+			}(),
+			env: map[string]string{"DT_TOKEN_CACHE_DIR": "/tmp/dt-tokens"},
+			want: providerSettings{
+				URL:           "https://api.example.com",
+				EmulatorURL:   defaultEmulatorURL,
+				KeyID:         "key",
+				KeySecret:     "secret",
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+				Email:         "service-account@example.com",
+				TokenCacheDir: "/tmp/dt-tokens",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			env := map[string]string{}
+			for key, value := range tt.env {
+				env[key] = value
+			}
+			got, diags := testResolver(t, env).resolve(tt.config)
+
+			gotErrors := errorAttributes(diags)
+			if len(gotErrors) != len(tt.wantErrors) {
+				t.Fatalf("expected errors on %v, got %v: %v", tt.wantErrors, gotErrors, diags)
+			}
+			for i := range gotErrors {
+				if gotErrors[i] != tt.wantErrors[i] {
+					t.Fatalf("expected errors on %v, got %v: %v", tt.wantErrors, gotErrors, diags)
+				}
+			}
+			if len(tt.wantErrors) > 0 {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMixedEnvironmentsDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	production := dtapi.Environments[dtapi.EnvironmentProduction]
+	tests := map[string]struct {
+		settings providerSettings
+		warning  bool
+	}{
+		"production": {
+			settings: providerSettings{
+				Environment:   "production",
+				URL:           production.URL,
+				EmulatorURL:   production.EmulatorURL,
+				TokenEndpoint: production.TokenEndpoint,
+			},
+		},
+		"endpoint outside the selected environment": {
+			settings: providerSettings{
+				Environment:   "production",
+				URL:           "https://api.example.com",
+				EmulatorURL:   production.EmulatorURL,
+				TokenEndpoint: production.TokenEndpoint,
+			},
+			warning: true,
+		},
+		"local emulator": {
+			settings: providerSettings{
+				Environment:   "production",
+				URL:           production.URL,
+				EmulatorURL:   "http://localhost:8080",
+				TokenEndpoint: production.TokenEndpoint,
+			},
+		},
+		"implied production": {
+			settings: providerSettings{
+				URL:         production.URL,
+				EmulatorURL: defaultEmulatorURL,
+				Issuer:      "https://identity.disruptive-technologies.com",
+			},
+		},
+		"mixed with implied production": {
+			settings: providerSettings{
+				URL:           production.URL,
+				EmulatorURL:   defaultEmulatorURL,
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+			},
+			warning: true,
+		},
+		"default emulator with custom endpoints": {
+			settings: providerSettings{
+				URL:           "https://api.example.com",
+				EmulatorURL:   defaultEmulatorURL,
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+			},
+			warning: true,
+		},
+		"custom endpoints": {
+			settings: providerSettings{
+				URL:           "https://api.example.com",
+				EmulatorURL:   "https://emulator.example.com",
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+			},
+		},
+		"custom environment": {
+			settings: providerSettings{
+				Environment:   "custom",
+				URL:           production.URL,
+				EmulatorURL:   "https://emulator.example.com",
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			diags := mixedEnvironmentsDiagnostics(tt.settings)
+			if got := diags.WarningsCount() > 0; got != tt.warning {
+				t.Errorf("expected warning %t, got %v", tt.warning, diags)
+			}
+		})
+	}
+}
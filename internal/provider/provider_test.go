@@ -3,9 +3,15 @@
 package provider
 
 import (
+	"errors"
+	"net/http"
 	"os"
 	"testing"
 
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi/oidc"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -38,3 +44,43 @@ func readTestFile(t *testing.T, filePath string) string {
 	}
 	return string(content)
 }
+
+func TestCredentialsDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err       error
+		attribute path.Path
+	}{
+		"rejected credentials": {
+			err:       &dtapi.TokenError{Err: &oidc.HTTPError{StatusCode: http.StatusBadRequest}},
+			attribute: path.Root("key_id"),
+		},
+		"unreachable token endpoint": {
+			err:       &dtapi.TokenError{Err: errors.New("connection refused")},
+			attribute: path.Root("token_endpoint"),
+		},
+		"unauthorized token": {
+			err:       &dtapi.HTTPError{StatusCode: http.StatusForbidden},
+			attribute: path.Root("key_id"),
+		},
+		"unreachable api": {
+			err:       errors.New("no such host"),
+			attribute: path.Root("url"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := credentialsDiagnostic(tt.err)
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			if !ok {
+				t.Fatalf("expected an attribute diagnostic, got %T", d)
+			}
+			if !withPath.Path().Equal(tt.attribute) {
+				t.Errorf("expected diagnostic on %s, got %s", tt.attribute, withPath.Path())
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &ruleNameFunction{}
+
+// NewRuleNameFunction is a helper function to simplify the provider implementation.
+func NewRuleNameFunction() function.Function {
+	return &ruleNameFunction{}
+}
+
+// ruleNameFunction is the function implementation.
+type ruleNameFunction struct{}
+
+func (f *ruleNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rule_name"
+}
+
+func (f *ruleNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the resource name of a rule",
+		MarkdownDescription: "Returns the resource name of a rule: `projects/{project_id}/rules/{rule_id}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "project_id",
+				Description: "The ID of the project the rule belongs to.",
+			},
+			function.StringParameter{
+				Name:        "rule_id",
+				Description: "The ID of the rule.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ruleNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var projectID, ruleID string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &projectID, &ruleID))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := dtapi.ValidateID(projectID); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid project ID: "+err.Error())
+		return
+	}
+	if err := dtapi.ValidateID(ruleID); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "invalid rule ID: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, dtapi.RuleName{ProjectID: projectID, RuleID: ruleID}.String()))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &scheduleFunction{}
+
+// timeOfDayAttributeTypes and scheduleAttributeTypes match the schedule
+// attribute of the dt_notification_rule resource.
+var (
+	timeOfDayAttributeTypes = map[string]attr.Type{
+		"hour":   types.Int32Type,
+		"minute": types.Int32Type,
+	}
+	scheduleAttributeTypes = map[string]attr.Type{
+		"timezone": types.StringType,
+		"inverse":  types.BoolType,
+		"slots": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+			"day_of_week": types.ListType{ElemType: types.StringType},
+			"time_range": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"start": types.ObjectType{AttrTypes: timeOfDayAttributeTypes},
+				"end":   types.ObjectType{AttrTypes: timeOfDayAttributeTypes},
+			}}},
+		}}},
+	}
+)
+
+// NewScheduleFunction is a helper function to simplify the provider implementation.
+func NewScheduleFunction() function.Function {
+	return &scheduleFunction{}
+}
+
+// scheduleFunction is the function implementation.
+type scheduleFunction struct{}
+
+func (f *scheduleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "schedule"
+}
+
+func (f *scheduleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a notification rule schedule from a compact syntax",
+		MarkdownDescription: "Returns an object that can be assigned to the `schedule` attribute of `dt_notification_rule`, " +
+			"built from a compact schedule such as `Mon-Fri 08:00-17:00; Sat 10:00-14:00`. " +
+			"Slots are separated by semicolons, and each slot is a comma separated list of days or day ranges " +
+			"followed by a comma separated list of `HH:MM-HH:MM` time ranges. Days are full names or their first three letters, " +
+			"and `daily` means every day.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "spec",
+				Description: "The compact schedule, e.g. `Mon-Fri 08:00-17:00; Sat 10:00-14:00`.",
+			},
+			function.StringParameter{
+				Name:        "timezone",
+				Description: "The time zone the schedule applies in, e.g. `Europe/Oslo`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: scheduleAttributeTypes,
+		},
+	}
+}
+
+func (f *scheduleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var spec, timezone string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &spec, &timezone))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := dtapi.ValidateTimezone(timezone); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	schedule, err := dtapi.ParseSchedule(spec, timezone)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, scheduleToState(schedule)))
+}
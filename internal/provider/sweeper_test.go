@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"testing"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Sweepers delete objects leaked by failed acceptance test runs. They sweep
+// every project in the organization given to -sweep, for example:
+//
+//	go test ./internal/provider -v -sweep=<organization id>
+//
+// Dependencies make sure rules and data connectors are swept before the
+// emulators they may reference, and emulators before their projects.
+
+// sweepDisplayName matches the display names used by the acceptance tests,
+// which all start with "Terraform created " in the examples and with
+// "Acceptance Test " in the test configs.
+var sweepDisplayName = regexp.MustCompile(`^(Terraform created|Acceptance Test) `)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("dt_notification_rule", &resource.Sweeper{
+		Name: "dt_notification_rule",
+		F:    sweepNotificationRules,
+	})
+	resource.AddTestSweepers("dt_data_connector", &resource.Sweeper{
+		Name: "dt_data_connector",
+		F:    sweepDataConnectors,
+	})
+	resource.AddTestSweepers("dt_emulator", &resource.Sweeper{
+		Name:         "dt_emulator",
+		F:            sweepEmulators,
+		Dependencies: []string{"dt_notification_rule", "dt_data_connector"},
+	})
+	resource.AddTestSweepers("dt_project", &resource.Sweeper{
+		Name:         "dt_project",
+		F:            sweepProjects,
+		Dependencies: []string{"dt_emulator"},
+	})
+}
+
+// sweepable reports whether an object was created by the acceptance tests.
+func sweepable(displayName string) bool {
+	return sweepDisplayName.MatchString(displayName)
+}
+
+// sweeperClient returns a client configured from the same environment
+// variables and profile as the provider.
+func sweeperClient() (*dtapi.Client, error) {
+	settings, diags := newConfigResolver().resolve(dtProviderModel{})
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to configure the sweeper client: %v", diags.Errors())
+	}
+	client, err := newProviderClient(settings, settings.TokenEndpoint, "sweeper")
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the sweeper client: %w", err)
+	}
+	return client.Client, nil
+}
+
+// sweepEachProject calls sweep for every project in the organization, and
+// returns all errors together so one failing project doesn't stop the sweep.
+func sweepEachProject(organizationID string, sweep func(ctx context.Context, client *dtapi.Client, project dtapi.Project) error) error {
+	ctx := context.Background()
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	projects, err := client.ListProjects(ctx, "organizations/"+organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var errs []error
+	for _, project := range projects {
+		if err := sweep(ctx, client, project); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepNotificationRules(organizationID string) error {
+	return sweepEachProject(organizationID, func(ctx context.Context, client *dtapi.Client, project dtapi.Project) error {
+		projectID, err := project.ID()
+		if err != nil {
+			return err
+		}
+		rules, err := client.ListNotificationRules(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		var errs []error
+		for _, rule := range rules {
+			if !sweepable(rule.DisplayName) {
+				continue
+			}
+			log.Printf("[INFO] deleting notification rule %s (%s)", rule.Name, rule.DisplayName)
+			if err := client.DeleteNotificationRule(ctx, rule.Name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+func sweepDataConnectors(organizationID string) error {
+	return sweepEachProject(organizationID, func(ctx context.Context, client *dtapi.Client, project dtapi.Project) error {
+		projectID, err := project.ID()
+		if err != nil {
+			return err
+		}
+		dataConnectors, err := client.ListDataConnectors(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		var errs []error
+		for _, dataConnector := range dataConnectors {
+			if !sweepable(dataConnector.DisplayName) {
+				continue
+			}
+			log.Printf("[INFO] deleting data connector %s (%s)", dataConnector.Name, dataConnector.DisplayName)
+			if err := client.DeleteDataConnector(ctx, dataConnector.Name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+func sweepEmulators(organizationID string) error {
+	return sweepEachProject(organizationID, func(ctx context.Context, client *dtapi.Client, project dtapi.Project) error {
+		projectID, err := project.ID()
+		if err != nil {
+			return err
+		}
+		emulators, err := client.ListEmulators(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		var errs []error
+		for _, emulator := range emulators {
+			if !sweepable(emulator.Labels["name"]) {
+				continue
+			}
+			log.Printf("[INFO] deleting emulator %s (%s)", emulator.Name, emulator.Labels["name"])
+			if err := client.DeleteEmulator(ctx, emulator.Name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+func sweepProjects(organizationID string) error {
+	return sweepEachProject(organizationID, func(ctx context.Context, client *dtapi.Client, project dtapi.Project) error {
+		if !sweepable(project.DisplayName) {
+			return nil
+		}
+		log.Printf("[INFO] deleting project %s (%s)", project.Name, project.DisplayName)
+		return client.DeleteProject(ctx, project.Name)
+	})
+}
+
+func TestSweepable(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		displayName string
+		want        bool
+	}{
+		"terraform created":           {displayName: "Terraform created project", want: true},
+		"acceptance test":             {displayName: "Acceptance Test data connector project", want: true},
+		"other":                       {displayName: "Fridge"},
+		"terraform created not first": {displayName: "Not Terraform created project"},
+		"acceptance test not first":   {displayName: "Fridge Acceptance Test"},
+		"prefix without space":        {displayName: "Acceptance Tests are running"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := sweepable(tt.displayName); got != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
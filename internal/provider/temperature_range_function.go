@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &temperatureRangeFunction{}
+
+// rangeAttributeTypes matches the trigger.range attribute of the dt_notification_rule resource.
+var rangeAttributeTypes = map[string]attr.Type{
+	"lower":  types.Float64Type,
+	"upper":  types.Float64Type,
+	"type":   types.StringType,
+	"filter": types.ObjectType{AttrTypes: filterModel{}.AttributeTypes(context.Background())},
+}
+
+// NewTemperatureRangeFunction is a helper function to simplify the provider implementation.
+func NewTemperatureRangeFunction() function.Function {
+	return &temperatureRangeFunction{}
+}
+
+// temperatureRangeFunction is the function implementation.
+type temperatureRangeFunction struct{}
+
+func (f *temperatureRangeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "temperature_range"
+}
+
+func (f *temperatureRangeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a notification rule temperature range",
+		MarkdownDescription: "Returns an object that can be assigned to the `trigger.range` attribute of `dt_notification_rule`, " +
+			"built from a spec such as `between 35F and 41F`. The accepted forms are `between A and B` and `outside A and B`, " +
+			"and `above A` and `below B` for ranges with a single bound. Temperatures are converted to Celsius and rounded to two " +
+			"decimals, temperatures without a unit are Celsius. The `filter` of the range is null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "spec",
+				Description: "The temperature range, e.g. `between 35F and 41F`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: rangeAttributeTypes,
+		},
+	}
+}
+
+func (f *temperatureRangeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var spec string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &spec))
+	if resp.Error != nil {
+		return
+	}
+
+	temperatureRange, err := dtapi.ParseTemperatureRange(spec)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result := rangeModel{
+		Lower:  types.Float64PointerValue(temperatureRange.Lower),
+		Upper:  types.Float64PointerValue(temperatureRange.Upper),
+		Type:   types.StringValue(temperatureRange.Type),
+		Filter: types.ObjectNull(filterModel{}.AttributeTypes(ctx)),
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
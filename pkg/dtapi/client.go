@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi/oidc"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Client is a client for the DT REST API and the DT emulator API. It
+// authenticates with a service account, and waits and retries when the API
+// responds with 429 Too Many Requests. Create one with New; a Client is safe
+// for concurrent use.
+type Client struct {
+	baseURL         string
+	emulatorBaseURL string
+	httpClient      *http.Client
+	oidc            *oidc.Client
+	retryAfter      *retryAfter
+	userAgent       string
+	readOnly        bool
+	rulesCache      *rulesCache
+	projectCache    *projectCache
+}
+
+type retryAfter struct {
+	// retryAfter is the time after which we can send another request
+	// after receiving a 429 Too Many Requests response
+	t  time.Time
+	mu sync.RWMutex
+}
+
+// ReadOnly reports whether the client refuses every request that isn't a GET.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// ErrReadOnly is returned for requests that would change something when the client is read only.
+var ErrReadOnly = errors.New("dt: client is read only")
+
+// HTTPError is returned when the DT API responds with a status other than
+// 200 OK, such as 404 Not Found.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP error: %d: %s", e.StatusCode, e.Body)
+}
+
+// time returns the retry after time.
+func (r *retryAfter) time() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.t
+}
+
+// setTime sets the retry after time to the given time.
+func (r *retryAfter) setTime(t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.t = t
+}
+
+// joinURL joins the path segments to the base URL. Each segment is path
+// escaped, so an ID can never change the path of the request, and a trailing
+// slash on base is ignored.
+func joinURL(base string, segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.Join(escaped, "/")
+}
+
+// apiURL returns the URL of the path segments on the DT API.
+func (c *Client) apiURL(segments ...string) string {
+	return joinURL(c.baseURL, segments...)
+}
+
+// emulatorURL returns the URL of the path segments on the DT emulator API.
+func (c *Client) emulatorURL(segments ...string) string {
+	return joinURL(c.emulatorBaseURL, segments...)
+}
+
+// DoRequest sends a request to url with the query params set and returns the
+// response body. It is the building block of the other methods, and can be
+// used for endpoints the client doesn't cover.
+func (c *Client) DoRequest(ctx context.Context, method, url string, requestBody []byte, params map[string]string) ([]byte, error) {
+	if c.readOnly && method != http.MethodGet {
+		return nil, fmt.Errorf("%w: refusing to send %s %s", ErrReadOnly, method, url)
+	}
+
+	// Check if we need to wait for the retry after time
+	// before sending the request
+	time.Sleep(time.Until(c.retryAfter.time()))
+
+	body := bytes.NewReader(requestBody)
+
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	query := request.URL.Query()
+	for key, value := range params {
+		query.Set(key, value)
+		ctx = tflog.SetField(ctx, key, value)
+	}
+	request.URL.RawQuery = query.Encode()
+
+	ctx = tflog.SetField(ctx, "method", method)
+	ctx = tflog.SetField(ctx, "url", url)
+
+	tflog.Debug(ctx, "sending request to DT API")
+
+	// Get an OIDC token and set it as a Bearer token in the request
+	token, err := c.oidc.GetToken(ctx)
+	if err != nil {
+		return nil, &TokenError{Err: err}
+	}
+	request.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", c.userAgent)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("dt: failed to send request: %w", err)
+	}
+	defer response.Body.Close()
+
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("dt: failed to read response body: %w, status: %d", err, response.StatusCode)
+	}
+	ctx = tflog.SetField(ctx, "status_code", response.StatusCode)
+	ctx = tflog.SetField(ctx, "body", string(bodyBytes))
+	if response.StatusCode != http.StatusOK {
+		tflog.Debug(ctx, "received non-200 status code from DT API")
+		if response.StatusCode == http.StatusTooManyRequests {
+			c.retryAfter.setTime(getRetryAfterTime(response))
+			tflog.Debug(ctx, "received 429 status code from DT API, retrying request")
+
+			// Retry the request
+			return c.DoRequest(ctx, method, url, requestBody, params)
+		}
+		return nil, &HTTPError{
+			StatusCode: response.StatusCode,
+			Body:       string(bodyBytes),
+		}
+	}
+
+	tflog.Debug(ctx, "received response from DT API")
+
+	return bodyBytes, nil
+}
+
+// getRetryAfterTime gets the retry after time from a request by parsing the Retry-After header.
+func getRetryAfterTime(res *http.Response) time.Time {
+	retryAfter := res.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return time.Now()
+	}
+	// Retry-After can be either a number of seconds or a date
+	retryAfterDuration, err := time.ParseDuration(retryAfter + "s")
+	if err != nil {
+		retryAfterTime, err := http.ParseTime(retryAfter)
+		if err != nil {
+			return time.Now()
+		}
+		return retryAfterTime
+	}
+	return time.Now().Add(retryAfterDuration)
+}
+
+// TokenError is returned by every request when no access token could be
+// obtained from the OIDC provider.
+type TokenError struct {
+	Err error
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("dt: failed to get OIDC token: %s", e.Err)
+}
+
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
+
+// AccessToken returns the access token the client authenticates to the DT API with.
+// Tokens are reused until shortly before they expire.
+func (c *Client) AccessToken(ctx context.Context) (*oidc.AuthResponse, error) {
+	token, err := c.oidc.GetToken(ctx)
+	if err != nil {
+		return nil, &TokenError{Err: err}
+	}
+	return token, nil
+}
+
+// ValidateCredentials checks that the client can obtain an access token and
+// that the token is accepted by the DT API, by listing a single organization.
+func (c *Client) ValidateCredentials(ctx context.Context) error {
+	if _, err := c.oidc.GetToken(ctx); err != nil {
+		return &TokenError{Err: err}
+	}
+
+	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/organizations
+	url := c.apiURL("v2", "organizations")
+	_, err := c.DoRequest(ctx, http.MethodGet, url, nil, map[string]string{"pageSize": "1"})
+	return err
+}
@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi/oidc"
+)
+
+// newTestClient returns a client that sends API, emulator and token requests
+// to the test server.
+func newTestClient(t *testing.T, serverURL string, opts ...Option) *Client {
+	t.Helper()
+
+	opts = append([]Option{
+		WithURL(serverURL),
+		WithEmulatorURL(serverURL),
+		WithTokenEndpoint(serverURL + "/oauth2/token"),
+		WithServiceAccountKey("key", "secret", "service-account@example.com"),
+	}, opts...)
+	client, err := New(opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestValidateCredentials(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tokenStatus int
+		apiStatus   int
+		check       func(t *testing.T, err error)
+	}{
+		"valid credentials": {
+			tokenStatus: http.StatusOK,
+			apiStatus:   http.StatusOK,
+			check: func(t *testing.T, err error) {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			},
+		},
+		"rejected credentials": {
+			tokenStatus: http.StatusBadRequest,
+			check: func(t *testing.T, err error) {
+				var tokenErr *TokenError
+				var oidcErr *oidc.HTTPError
+				if !errors.As(err, &tokenErr) || !errors.As(err, &oidcErr) {
+					t.Errorf("expected a token error, got: %v", err)
+				}
+			},
+		},
+		"unauthorized token": {
+			tokenStatus: http.StatusOK,
+			apiStatus:   http.StatusUnauthorized,
+			check: func(t *testing.T, err error) {
+				var httpErr *HTTPError
+				if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+					t.Errorf("expected an unauthorized error, got: %v", err)
+				}
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/oauth2/token":
+					w.WriteHeader(tt.tokenStatus)
+					fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+				case "/v2/organizations":
+					if r.Header.Get("Authorization") != "Bearer access-token" {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					w.WriteHeader(tt.apiStatus)
+					fmt.Fprint(w, `{"organizations":[]}`)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server.URL)
+			tt.check(t, client.ValidateCredentials(context.Background()))
+		})
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+
+	var apiRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+			return
+		}
+		apiRequests.Add(1)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, WithReadOnly(true))
+
+	ctx := context.Background()
+	if _, err := client.DoRequest(ctx, http.MethodGet, server.URL+"/v2/projects/project-id", nil, nil); err != nil {
+		t.Errorf("unexpected error for GET: %v", err)
+	}
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		_, err := client.DoRequest(ctx, method, server.URL+"/v2/projects/project-id", []byte(`{}`), nil)
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected %s to be refused, got: %v", method, err)
+		}
+	}
+	if got := apiRequests.Load(); got != 1 {
+		t.Errorf("expected only the GET request to reach the API, got %d requests", got)
+	}
+}
+
+func TestAccessToken(t *testing.T) {
+	t.Parallel()
+
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequests.Add(1) > 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	// the second call reuses the token rather than failing against the server
+	for range 2 {
+		token, err := client.AccessToken(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token.AccessToken != "access-token" || token.TokenType != "Bearer" || token.ExpiresIn <= 0 {
+			t.Errorf("unexpected token: %+v", token)
+		}
+	}
+
+	client = newTestClient(t, server.URL)
+	var tokenErr *TokenError
+	if _, err := client.AccessToken(context.Background()); !errors.As(err, &tokenErr) {
+		t.Errorf("expected a TokenError, got: %v", err)
+	}
+}
+
+func TestRequestTokenError(t *testing.T) {
+	t.Parallel()
+
+	var apiRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		apiRequests.Add(1)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	_, err := client.ListDataConnectors(context.Background(), "p1")
+
+	var tokenErr *TokenError
+	var oidcErr *oidc.HTTPError
+	if !errors.As(err, &tokenErr) || !errors.As(err, &oidcErr) || oidcErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected a TokenError wrapping the OIDC error, got: %v", err)
+	}
+	if got := apiRequests.Load(); got != 0 {
+		t.Errorf("expected no request to reach the API, got %d requests", got)
+	}
+}
+
+func TestListDataConnectors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		case r.URL.Path != "/v2/projects/p1/dataconnectors":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Query().Get("pageToken") == "":
+			fmt.Fprint(w, `{"dataConnectors":[{"name":"projects/p1/dataconnectors/dc1"}],"nextPageToken":"page-2"}`)
+		default:
+			fmt.Fprint(w, `{"dataConnectors":[{"name":"projects/p1/dataconnectors/dc2"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	dataConnectors, err := client.ListDataConnectors(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dataConnectors) != 2 || dataConnectors[0].Name != "projects/p1/dataconnectors/dc1" || dataConnectors[1].Name != "projects/p1/dataconnectors/dc2" {
+		t.Errorf("expected both pages of data connectors, got: %+v", dataConnectors)
+	}
+}
+
+func TestPublishEmulatorEvent(t *testing.T) {
+	t.Parallel()
+
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		case "/v2/projects/p1/devices/d1:publish":
+			body, _ := io.ReadAll(r.Body)
+			gotBody = string(body)
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The emulator URL the provider defaults to ends with a slash. Requests
+	// are sent to the test server, keeping the path they were built with.
+	var gotURLs []string
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse the server URL: %v", err)
+	}
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		gotURLs = append(gotURLs, r.URL.String())
+		r = r.Clone(r.Context())
+		r.URL.Scheme = serverURL.Scheme
+		r.URL.Host = serverURL.Host
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	client := newTestClient(t, server.URL, WithEmulatorURL("https://emulator.disruptive-technologies.com/"), WithHTTPClient(httpClient))
+
+	err = client.PublishEmulatorEvent(context.Background(), "projects/p1/devices/d1", "temperature", json.RawMessage(`{"value":22.5}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "https://emulator.disruptive-technologies.com/v2/projects/p1/devices/d1:publish"; !slices.Contains(gotURLs, want) {
+		t.Errorf("expected a request to %s, got %v", want, gotURLs)
+	}
+	if want := `{"temperature":{"value":22.5}}`; gotBody != want {
+		t.Errorf("expected body %s, got %s", want, gotBody)
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper calling the function.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestJoinURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		base     string
+		segments []string
+		want     string
+	}{
+		"base":           {base: "https://api.example.com", segments: []string{"v2", "projects"}, want: "https://api.example.com/v2/projects"},
+		"trailing slash": {base: "https://api.example.com/", segments: []string{"v2", "projects"}, want: "https://api.example.com/v2/projects"},
+		"base path":      {base: "https://example.com/api/", segments: []string{"v2", "projects", "p1"}, want: "https://example.com/api/v2/projects/p1"},
+		"escaped":        {base: "https://api.example.com", segments: []string{"v2", "projects", "p1/../p2", "rules", "r 1?"}, want: "https://api.example.com/v2/projects/p1%2F..%2Fp2/rules/r%201%3F"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := joinURL(tt.base, tt.segments...); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
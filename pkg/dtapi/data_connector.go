@@ -1,15 +1,15 @@
 // Copyright (c) HashiCorp, Inc.
 
-package dt
+package dtapi
 
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"strings"
 )
 
+// DataConnector forwards the events of the devices in a project to an external
+// service. Name is the resource name, projects/{project}/dataconnectors/{id}.
 type DataConnector struct {
 	Name                  string                 `json:"name"`
 	Type                  string                 `json:"type"`
@@ -24,51 +24,65 @@ type DataConnector struct {
 	AWSSQSConfig          *AWSSQSConfig          `json:"awsSqsConfig"`
 }
 
+// ListDataConnectorsResponse is a page of data connectors.
+type ListDataConnectorsResponse struct {
+	DataConnectors []DataConnector `json:"dataConnectors"`
+	NextPageToken  string          `json:"nextPageToken"`
+}
+
+// HTTPConfig configures an HTTP_PUSH data connector.
 type HTTPConfig struct {
 	Url             string            `json:"url"`
 	SignatureSecret string            `json:"signatureSecret"`
 	Headers         map[string]string `json:"headers"`
 }
 
+// AzureServiceBusConfig configures an AZURE_SERVICE_BUS data connector.
 type AzureServiceBusConfig struct {
 	URL                  string               `json:"url"`
 	AuthenticationConfig AuthenticationConfig `json:"authenticationConfig"`
 	BrokerProperties     BrokerProperties     `json:"brokerProperties"`
 }
 
+// BrokerProperties are the properties set on Azure Service Bus messages.
 type BrokerProperties struct {
 	CorrelationID string `json:"correlationId"`
 }
 
+// AzureEventHubConfig configures an AZURE_EVENT_HUB data connector.
 type AzureEventHubConfig struct {
 	URL                  string               `json:"url"`
 	AuthenticationConfig AuthenticationConfig `json:"authenticationConfig"`
 }
 
+// AuthenticationConfig identifies the Azure application a data connector
+// authenticates as.
 type AuthenticationConfig struct {
 	TenantID string `json:"tenantId"`
 	ClientID string `json:"clientId"`
 }
 
+// PubsubConfig configures a GOOGLE_CLOUD_PUBSUB data connector.
 type PubsubConfig struct {
 	Topic    string `json:"topic"`
 	Audience string `json:"audience"`
 }
 
+// AWSSQSConfig configures an AWS_SQS data connector.
 type AWSSQSConfig struct {
 	QueueUrl   string `json:"queueUrl"`
 	AwsRoleArn string `json:"awsRoleArn"`
 	Audience   string `json:"audience"`
 }
 
-// GetDatConnector retrieves a data connector by name.
+// GetDataConnector retrieves a data connector by name.
 func (c *Client) GetDataConnector(ctx context.Context, dataConnector string) (DataConnector, error) {
-	projectID, dataConnectorID, err := parseDataConnectorResourceName(dataConnector)
+	dataConnectorName, err := ParseDataConnectorName(dataConnector)
 	if err != nil {
 		return DataConnector{}, err
 	}
 	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects/{project_id}/dataconnectors/{data_connector_id}
-	url := fmt.Sprintf("%s/v2/projects/%s/dataconnectors/%s", strings.TrimSuffix(c.URL, "/"), projectID, dataConnectorID)
+	url := c.apiURL("v2", "projects", dataConnectorName.ProjectID, "dataconnectors", dataConnectorName.DataConnectorID)
 
 	// Send a GET request to the API
 	responseBody, err := c.DoRequest(ctx, http.MethodGet, url, nil, nil)
@@ -85,6 +99,36 @@ func (c *Client) GetDataConnector(ctx context.Context, dataConnector string) (Da
 	return dc, nil
 }
 
+// ListDataConnectors lists the data connectors in a project, following page tokens.
+func (c *Client) ListDataConnectors(ctx context.Context, projectID string) ([]DataConnector, error) {
+	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects/{project_id}/dataconnectors
+	url := c.apiURL("v2", "projects", projectID, "dataconnectors")
+	params := map[string]string{
+		"pageSize":  "100",
+		"pageToken": "",
+	}
+
+	var dataConnectors []DataConnector
+	for {
+		responseBody, err := c.DoRequest(ctx, http.MethodGet, url, nil, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var response ListDataConnectorsResponse
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return nil, err
+		}
+		dataConnectors = append(dataConnectors, response.DataConnectors...)
+
+		if response.NextPageToken == "" {
+			return dataConnectors, nil
+		}
+		params["pageToken"] = response.NextPageToken
+	}
+}
+
+// CreateDataConnectorRequest is the request body for creating a data connector.
 type CreateDataConnectorRequest struct {
 	DisplayName           string                 `json:"displayName"`
 	Type                  string                 `json:"type"`
@@ -119,7 +163,7 @@ func (c *Client) CreateDataConnector(ctx context.Context, projectID string, data
 	request := dataConnectorToCreateDataConnectorRequest(dataConnector)
 
 	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects/{project_id}/dataconnectors
-	url := fmt.Sprintf("%s/v2/projects/%s/dataconnectors", strings.TrimSuffix(c.URL, "/"), projectID)
+	url := c.apiURL("v2", "projects", projectID, "dataconnectors")
 	body, err := json.Marshal(request)
 	if err != nil {
 		return DataConnector{}, err
@@ -143,13 +187,13 @@ func (c *Client) CreateDataConnector(ctx context.Context, projectID string, data
 
 // UpdateDataConnector updates an existing data connector.
 func (c *Client) UpdateDataConnector(ctx context.Context, dc DataConnector) (DataConnector, error) {
-	projectID, dataConnectorID, err := parseDataConnectorResourceName(dc.Name)
+	dataConnectorName, err := ParseDataConnectorName(dc.Name)
 	if err != nil {
 		return DataConnector{}, err
 	}
 
 	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects/{project_id}/dataconnectors/{data_connector_id}
-	url := fmt.Sprintf("%s/v2/projects/%s/dataconnectors/%s", strings.TrimSuffix(c.URL, "/"), projectID, dataConnectorID)
+	url := c.apiURL("v2", "projects", dataConnectorName.ProjectID, "dataconnectors", dataConnectorName.DataConnectorID)
 	body, err := json.Marshal(dc)
 	if err != nil {
 		return DataConnector{}, err
@@ -173,13 +217,13 @@ func (c *Client) UpdateDataConnector(ctx context.Context, dc DataConnector) (Dat
 
 // DeleteDataConnector deletes a data connector.
 func (c *Client) DeleteDataConnector(ctx context.Context, dataConnector string) error {
-	projectID, dataConnectorID, err := parseDataConnectorResourceName(dataConnector)
+	dataConnectorName, err := ParseDataConnectorName(dataConnector)
 	if err != nil {
 		return err
 	}
 
 	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects/{project_id}/dataconnectors/{data_connector_id}
-	url := fmt.Sprintf("%s/v2/projects/%s/dataconnectors/%s", strings.TrimSuffix(c.URL, "/"), projectID, dataConnectorID)
+	url := c.apiURL("v2", "projects", dataConnectorName.ProjectID, "dataconnectors", dataConnectorName.DataConnectorID)
 
 	// Send a DELETE request to the API
 	_, err = c.DoRequest(ctx, http.MethodDelete, url, nil, nil)
@@ -190,21 +234,28 @@ func (c *Client) DeleteDataConnector(ctx context.Context, dataConnector string)
 	return nil
 }
 
-func (d DataConnector) ProjectID() string {
-	projectID, _, _ := parseDataConnectorResourceName(d.Name)
-	return projectID
+// SyncDataConnector sends the most recent events of all devices in the project
+// through the data connector.
+func (c *Client) SyncDataConnector(ctx context.Context, dataConnector string) error {
+	dataConnectorName, err := ParseDataConnectorName(dataConnector)
+	if err != nil {
+		return err
+	}
+
+	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects/{project_id}/dataconnectors/{data_connector_id}:sync
+	url := c.apiURL("v2", "projects", dataConnectorName.ProjectID, "dataconnectors", dataConnectorName.DataConnectorID) + ":sync"
+
+	// Send a POST request to the API
+	_, err = c.DoRequest(ctx, http.MethodPost, url, []byte(`{}`), nil)
+	return err
 }
 
-func (d DataConnector) DataConnectorID() string {
-	_, dataConnectorID, _ := parseDataConnectorResourceName(d.Name)
-	return dataConnectorID
+func (d DataConnector) ProjectID() string {
+	name, _ := ParseDataConnectorName(d.Name)
+	return name.ProjectID
 }
 
-// parseDataConnectorResourceName parses a data connector resource name into projectID and dataConnectorID.
-func parseDataConnectorResourceName(name string) (projectID string, dataConnectorID string, err error) {
-	parts := strings.Split(name, "/")
-	if len(parts) != 4 {
-		return "", "", fmt.Errorf("invalid data connector name: %s", name)
-	}
-	return parts[1], parts[3], nil
+func (d DataConnector) DataConnectorID() string {
+	name, _ := ParseDataConnectorName(d.Name)
+	return name.DataConnectorID
 }
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Device is a sensor or cloud connector. Name is the resource name,
+// projects/{project}/devices/{id}.
+type Device struct {
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	Labels        map[string]string `json:"labels"`
+	ProductNumber string            `json:"productNumber"`
+}
+
+// ListDevicesResponse is a page of devices.
+type ListDevicesResponse struct {
+	Devices       []Device `json:"devices"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+// GetDevice retrieves a device by name.
+func (c *Client) GetDevice(ctx context.Context, name string) (*Device, error) {
+	deviceName, err := ParseDeviceName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	url := c.apiURL("v2", "projects", deviceName.ProjectID, "devices", deviceName.DeviceID)
+	responseBody, err := c.DoRequest(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("dt: failed to get device: %w", err)
+	}
+
+	var device Device
+	if err := json.Unmarshal(responseBody, &device); err != nil {
+		return nil, fmt.Errorf("dt: failed to unmarshal device: %w", err)
+	}
+
+	return &device, nil
+}
+
+// ListDevices lists the devices in a project, following page tokens.
+func (c *Client) ListDevices(ctx context.Context, projectID string) ([]Device, error) {
+	url := c.apiURL("v2", "projects", projectID, "devices")
+	params := map[string]string{
+		"pageSize":  "100",
+		"pageToken": "",
+	}
+
+	var devices []Device
+	for {
+		responseBody, err := c.DoRequest(ctx, "GET", url, nil, params)
+		if err != nil {
+			return nil, fmt.Errorf("dt: failed to list devices: %w", err)
+		}
+
+		var response ListDevicesResponse
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return nil, fmt.Errorf("dt: failed to unmarshal devices: %w", err)
+		}
+		devices = append(devices, response.Devices...)
+
+		if response.NextPageToken == "" {
+			return devices, nil
+		}
+		params["pageToken"] = response.NextPageToken
+	}
+}
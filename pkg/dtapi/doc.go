@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+
+// Package dtapi is a Go client for the Disruptive Technologies (DT) REST API
+// and emulator API. It is the client the Terraform provider uses, published
+// for tools such as backfills and audits.
+//
+// Create a client with New and configure it with options. The client
+// authenticates with a service account key, reusing access tokens until
+// shortly before they expire, and waits and retries when the API responds with
+// 429 Too Many Requests:
+//
+//	client, err := dtapi.New(
+//		dtapi.WithEnvironment(dtapi.EnvironmentProduction),
+//		dtapi.WithServiceAccountKey(keyID, secret, email),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	projects, err := client.ListProjects(ctx, "organizations/"+organizationID)
+//
+// Resources are identified by their resource name, such as
+// projects/{project}/devices/{device}. The typed names, such as DeviceName,
+// parse and build them.
+//
+// Every method returns errors from the API as *HTTPError, and failures to get
+// an access token as *TokenError, so they can be inspected with errors.As.
+//
+// # Compatibility
+//
+// The package follows semantic versioning together with the provider module.
+// Exported identifiers are only removed or changed incompatibly in a new
+// major version, and such changes are listed in the changelog. Everything
+// under internal/ may change at any time.
+package dtapi
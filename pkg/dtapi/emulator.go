@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Emulator is an emulated device. Name is the resource name,
+// projects/{project}/devices/{id}, and the display name is the "name" label.
+type Emulator struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Labels map[string]string `json:"labels"`
+}
+
+// ListEmulatorsResponse is a page of emulated devices.
+type ListEmulatorsResponse struct {
+	Emulators     []Emulator `json:"devices"`
+	NextPageToken string     `json:"nextPageToken"`
+}
+
+// GetEmulator retrieves an emulated device by name.
+func (c *Client) GetEmulator(ctx context.Context, name string) (Emulator, error) {
+	deviceName, err := ParseDeviceName(name)
+	if err != nil {
+		return Emulator{}, err
+	}
+
+	url := c.emulatorURL("v2", "projects", deviceName.ProjectID, "devices", deviceName.DeviceID)
+	responseBody, err := c.DoRequest(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return Emulator{}, err
+	}
+
+	var emulator Emulator
+	if err := json.Unmarshal(responseBody, &emulator); err != nil {
+		return Emulator{}, err
+	}
+	return emulator, nil
+}
+
+// ListEmulators lists the emulated devices in a project, following page tokens.
+func (c *Client) ListEmulators(ctx context.Context, projectID string) ([]Emulator, error) {
+	url := c.emulatorURL("v2", "projects", projectID, "devices")
+	params := map[string]string{
+		"pageSize":  "100",
+		"pageToken": "",
+	}
+
+	var emulators []Emulator
+	for {
+		responseBody, err := c.DoRequest(ctx, "GET", url, nil, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var response ListEmulatorsResponse
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return nil, err
+		}
+		emulators = append(emulators, response.Emulators...)
+
+		if response.NextPageToken == "" {
+			return emulators, nil
+		}
+		params["pageToken"] = response.NextPageToken
+	}
+}
+
+// CreateEmulator creates an emulated device in the project.
+func (c *Client) CreateEmulator(ctx context.Context, projectID string, emulatorToBeCreated Emulator) (Emulator, error) {
+	body, err := json.Marshal(emulatorToBeCreated)
+	if err != nil {
+		return Emulator{}, err
+	}
+
+	url := c.emulatorURL("v2", "projects", projectID, "devices")
+	responseBody, err := c.DoRequest(ctx, "POST", url, body, nil)
+	if err != nil {
+		return Emulator{}, err
+	}
+
+	var createdEmulator Emulator
+	if err := json.Unmarshal(responseBody, &createdEmulator); err != nil {
+		return Emulator{}, err
+	}
+	return createdEmulator, nil
+}
+
+// DeleteEmulator deletes an emulated device.
+func (c *Client) DeleteEmulator(ctx context.Context, name string) error {
+	deviceName, err := ParseDeviceName(name)
+	if err != nil {
+		return err
+	}
+
+	url := c.emulatorURL("v2", "projects", deviceName.ProjectID, "devices", deviceName.DeviceID)
+	_, err = c.DoRequest(ctx, "DELETE", url, nil, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateEmulator updates the labels of an emulated device.
+func (c *Client) UpdateEmulator(ctx context.Context, emulator Emulator) (Emulator, error) {
+	deviceName, err := ParseDeviceName(emulator.Name)
+	if err != nil {
+		return Emulator{}, err
+	}
+
+	url := c.emulatorURL("v2", "projects", deviceName.ProjectID, "devices", deviceName.DeviceID)
+
+	body, err := json.Marshal(emulator)
+	if err != nil {
+		return Emulator{}, err
+	}
+
+	responseBody, err := c.DoRequest(ctx, "PUT", url, body, nil)
+	if err != nil {
+		return Emulator{}, err
+	}
+
+	var updatedEmulator Emulator
+	if err := json.Unmarshal(responseBody, &updatedEmulator); err != nil {
+		return Emulator{}, err
+	}
+	return updatedEmulator, nil
+}
+
+// PublishEmulatorEvent publishes an event of the event type, such as temperature or
+// touch, on the emulated device. data is the JSON object of the event, such as
+// {"value": 22.5} for a temperature event.
+func (c *Client) PublishEmulatorEvent(ctx context.Context, name, eventType string, data json.RawMessage) error {
+	deviceName, err := ParseDeviceName(name)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]json.RawMessage{eventType: data})
+	if err != nil {
+		return err
+	}
+
+	url := c.emulatorURL("v2", "projects", deviceName.ProjectID, "devices", deviceName.DeviceID) + ":publish"
+	_, err = c.DoRequest(ctx, "POST", url, body, nil)
+	return err
+}
+
+func (e *Emulator) ProjectID() string {
+	name, _ := ParseDeviceName(e.Name)
+	return name.ProjectID
+}
+
+func (e *Emulator) DeviceID() string {
+	name, _ := ParseDeviceName(e.Name)
+	return name.DeviceID
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Names of the DT environments.
+const (
+	EnvironmentProduction = "production"
+	// EnvironmentCustom has no preset endpoints, every URL must be configured.
+	EnvironmentCustom = "custom"
+)
+
+// Environment is a consistent set of endpoints for one DT environment.
+type Environment struct {
+	Name          string
+	URL           string
+	EmulatorURL   string
+	TokenEndpoint string
+}
+
+// Environments are the DT environments with preset endpoints.
+var Environments = map[string]Environment{
+	EnvironmentProduction: {
+		Name:          EnvironmentProduction,
+		URL:           "https://api.disruptive-technologies.com",
+		EmulatorURL:   "https://emulator.disruptive-technologies.com",
+		TokenEndpoint: "https://identity.disruptive-technologies.com/oauth2/token",
+	},
+}
+
+// EnvironmentNames returns the names accepted for the environment setting.
+func EnvironmentNames() []string {
+	return []string{EnvironmentProduction, EnvironmentCustom}
+}
+
+// Contains reports whether the URL belongs to the environment, based on its
+// host.
+func (e Environment) Contains(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, endpoint := range []string{e.URL, e.EmulatorURL, e.TokenEndpoint} {
+		if u, err := url.Parse(endpoint); err == nil && u.Hostname() == host {
+			return true
+		}
+	}
+	return false
+}
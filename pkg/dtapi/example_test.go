@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi"
+)
+
+func ExampleNew() {
+	// A stand-in for the DT API and token endpoint.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		case "/v2/projects":
+			fmt.Fprint(w, `{"projects":[{"name":"projects/c8n5cmpsd1f4ma0p5jt0","displayName":"Warehouse"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := dtapi.New(
+		dtapi.WithURL(server.URL),
+		dtapi.WithTokenEndpoint(server.URL+"/oauth2/token"),
+		dtapi.WithServiceAccountKey("key-id", "key-secret", "service-account@example.com"),
+		dtapi.WithUserAgent("audit/1.0"),
+		dtapi.WithReadOnly(true),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	projects, err := client.ListProjects(context.Background(), "organizations/c8n5cmpsd1f4ma0p5jt1")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, project := range projects {
+		id, _ := project.ID()
+		fmt.Println(id, project.DisplayName)
+	}
+	// Output: c8n5cmpsd1f4ma0p5jt0 Warehouse
+}
+
+func ExampleParseDeviceName() {
+	name, err := dtapi.ParseDeviceName("projects/c8n5cmpsd1f4ma0p5jt0/devices/emuc8n5cmpsd1f4ma0p5jt2")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(name.ProjectID, name.DeviceID)
+	// Output: c8n5cmpsd1f4ma0p5jt0 emuc8n5cmpsd1f4ma0p5jt2
+}
@@ -1,44 +1,55 @@
 // Copyright (c) HashiCorp, Inc.
 
-package dt
+package dtapi
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// BatchCreateProjectsMembersRequest is the request body for adding members to
+// several projects at once.
 type BatchCreateProjectsMembersRequest struct {
 	Members []Members `json:"members"`
 }
 
+// Members adds the member with the email to a project with the roles.
 type Members struct {
 	Project string   `json:"project"`
 	Email   string   `json:"email"`
 	Roles   []string `json:"roles"`
 }
 
+// BatchDeleteProjectMembersRequest is the request body for removing members
+// by name.
 type BatchDeleteProjectMembersRequest struct {
 	Names []string `json:"names"`
 }
 
+// CreateProjectMemberRequest is the request body for adding a member to a project.
 type CreateProjectMemberRequest struct {
 	Roles []string `json:"roles"`
 	Email string   `json:"email"`
 }
 
+// MembershipResponse is the response to a batch request on project members.
 type MembershipResponse struct {
 	Memberships []Membership `json:"members"`
 }
 
+// ListProjectMembersResponse is a page of project members.
 type ListProjectMembersResponse struct {
 	Members       []Membership `json:"members"`
 	NextPageToken string       `json:"nextPageToken"`
 }
 
+// Membership is the membership of a user or service account in a project. Name
+// is the resource name, projects/{project}/members/{id}.
 type Membership struct {
 	Name        string   `json:"name"`
 	DisplayName string   `json:"displayName"`
@@ -47,35 +58,80 @@ type Membership struct {
 	AccountType string   `json:"accountType"`
 }
 
+// ProjectID returns the ID of the project of the membership.
 func (m Membership) ProjectID() (string, error) {
-	projectID, _, err := ParseResourceName(m.Name)
+	name, err := ParseMemberName(m.Name)
 	if err != nil {
 		return "", fmt.Errorf("dt: failed to parse resource name: %w", err)
 	}
-	return projectID, nil
+	return name.ProjectID, nil
 }
 
+// ID returns the ID of the member.
 func (m Membership) ID() (string, error) {
-	_, memberID, err := ParseResourceName(m.Name)
+	name, err := ParseMemberName(m.Name)
 	if err != nil {
 		return "", fmt.Errorf("dt: failed to parse resource name: %w", err)
 	}
-	return memberID, nil
+	return name.MemberID, nil
 }
 
 // ListProjectMemberships lists all memberships for a given organization and member.
 func (c *Client) ListProjectMemberships(ctx context.Context, organization, role, memberID string) ([]Membership, error) {
-	var members []Membership
-
-	params := map[string]string{
+	members, err := c.listProjectMemberships(ctx, map[string]string{
 		"memberId":     memberID,
 		"organization": organization,
-		"pageSize":     "100", // Default page size
-		"pageToken":    "",
+	})
+	if err != nil {
+		return nil, err
 	}
+	tflog.Debug(ctx, fmt.Sprintf("dt: found %d memberships for member %s in organization %s", len(members), memberID, organization))
+
+	// Filter out memberships that do not match the specified role:
+	filteredMembers := make([]Membership, 0, len(members))
+	for _, member := range members {
+		if len(member.Roles) != 1 {
+			return nil, fmt.Errorf("dt: expected exactly one role for member %s in organization %s, got %d roles", memberID, organization, len(member.Roles))
+		}
+		if member.Roles[0] == role {
+			filteredMembers = append(filteredMembers, member)
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("dt: found %d memberships with role %s for member %s in organization %s", len(filteredMembers), role, memberID, organization))
+
+	return filteredMembers, nil
+}
+
+// ListProjectMembershipsByEmail lists all memberships in the organization of members with the email.
+func (c *Client) ListProjectMembershipsByEmail(ctx context.Context, organization, email string) ([]Membership, error) {
+	members, err := c.listProjectMemberships(ctx, map[string]string{
+		"organization": organization,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filteredMembers := make([]Membership, 0, len(members))
+	for _, member := range members {
+		if strings.EqualFold(member.Email, email) {
+			filteredMembers = append(filteredMembers, member)
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("dt: found %d memberships for email %s in organization %s", len(filteredMembers), email, organization))
+
+	return filteredMembers, nil
+}
+
+// listProjectMemberships lists the memberships matching params across all projects, following page tokens.
+func (c *Client) listProjectMemberships(ctx context.Context, params map[string]string) ([]Membership, error) {
+	var members []Membership
+
+	params["pageSize"] = "100" // Default page size
+	params["pageToken"] = ""
 
 	// use the project wildcard to list all memberships across all projects in the organization:
-	url := c.URL + "/v2/projects/-/members"
+	url := c.apiURL("v2", "projects", "-", "members")
 
 	for {
 		responseBody, err := c.DoRequest(ctx, http.MethodGet, url, nil, params)
@@ -96,28 +152,14 @@ func (c *Client) ListProjectMemberships(ctx context.Context, organization, role,
 		// If there is a next page token, set it for the next request:
 		params["pageToken"] = member.NextPageToken
 	}
-	tflog.Debug(ctx, fmt.Sprintf("dt: found %d memberships for member %s in organization %s", len(members), memberID, organization))
-
-	// Filter out memberships that do not match the specified role:
-	filteredMembers := make([]Membership, 0, len(members))
-	for _, member := range members {
-		if len(member.Roles) != 1 {
-			return nil, fmt.Errorf("dt: expected exactly one role for member %s in organization %s, got %d roles", memberID, organization, len(member.Roles))
-		}
-		if member.Roles[0] == role {
-			filteredMembers = append(filteredMembers, member)
-		}
-	}
 
-	tflog.Debug(ctx, fmt.Sprintf("dt: found %d memberships with role %s for member %s in organization %s", len(filteredMembers), role, memberID, organization))
-
-	return filteredMembers, nil
+	return members, nil
 }
 
 // BatchCreateMemberships creates multiple project memberships in a single request.
 // This is to avoid sending out multiple emails for each project membership.
 func (c *Client) BatchCreateMemberships(ctx context.Context, req BatchCreateProjectsMembersRequest) ([]Membership, error) {
-	url := c.URL + "/v2/projects/-/members:batchCreate"
+	url := c.apiURL("v2", "projects", "-", "members") + ":batchCreate"
 
 	requestBody, err := json.Marshal(req)
 	if err != nil {
@@ -137,6 +179,7 @@ func (c *Client) BatchCreateMemberships(ctx context.Context, req BatchCreateProj
 	return response.Memberships, nil
 }
 
+// UpdateProjectMemberRequest is the request body for changing the roles of a member.
 type UpdateProjectMemberRequest struct {
 	Roles []string `json:"roles"`
 }
@@ -145,7 +188,7 @@ type UpdateProjectMemberRequest struct {
 func (c *Client) UpdateMemberships(ctx context.Context, memberships []Membership, role string) ([]Membership, error) {
 	updatedMembers := make([]Membership, 0, len(memberships))
 	for _, member := range memberships {
-		projectID, memberID, err := ParseResourceName(member.Name)
+		memberName, err := ParseMemberName(member.Name)
 		if err != nil {
 			return nil, fmt.Errorf("dt: failed to parse resource name: %w", err)
 		}
@@ -153,7 +196,7 @@ func (c *Client) UpdateMemberships(ctx context.Context, memberships []Membership
 		// This api only allows a single role to be set for a member:
 		member.Roles = []string{role}
 
-		url := c.URL + "/v2/projects/" + projectID + "/members/" + memberID
+		url := c.apiURL("v2", "projects", memberName.ProjectID, "members", memberName.MemberID)
 		requestBody, err := json.Marshal(member)
 		if err != nil {
 			return nil, fmt.Errorf("dt: failed to marshal memberships: %w", err)
@@ -176,7 +219,7 @@ func (c *Client) UpdateMemberships(ctx context.Context, memberships []Membership
 }
 
 func (c *Client) BatchDeleteMemberships(ctx context.Context, req BatchDeleteProjectMembersRequest) error {
-	url := c.URL + "/v2/projects/-/members:batchDelete"
+	url := c.apiURL("v2", "projects", "-", "members") + ":batchDelete"
 
 	requestBody, err := json.Marshal(req)
 	if err != nil {
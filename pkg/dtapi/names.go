@@ -0,0 +1,240 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceName is a parsed DT resource name. Resource names alternate between
+// collection and ID segments, such as projects/{project_id}/devices/{device_id}.
+type ResourceName struct {
+	// Collection is the collection of the resource, e.g. devices.
+	Collection string
+	// ID is the ID of the resource within its collection.
+	ID string
+	// Parent is the resource name of the parent, or empty for top-level resources.
+	Parent string
+	// ProjectID is the ID of the project the resource belongs to, if any.
+	ProjectID string
+	// OrganizationID is the ID of the organization the resource belongs to, if any.
+	OrganizationID string
+}
+
+// ParseName parses a DT resource name. Every segment must be non-empty and
+// the name must start with projects/ or organizations/.
+func ParseName(name string) (ResourceName, error) {
+	parts := strings.Split(name, "/")
+	if len(parts)%2 != 0 {
+		return ResourceName{}, fmt.Errorf("dt: invalid resource name %q: expected collection/id pairs", name)
+	}
+	if parts[0] != "projects" && parts[0] != "organizations" {
+		return ResourceName{}, fmt.Errorf("dt: invalid resource name %q: must start with projects/ or organizations/", name)
+	}
+	for _, part := range parts {
+		if err := validateSegment(part); err != nil {
+			return ResourceName{}, fmt.Errorf("dt: invalid resource name %q: %w", name, err)
+		}
+	}
+
+	resourceName := ResourceName{
+		Collection: parts[len(parts)-2],
+		ID:         parts[len(parts)-1],
+		Parent:     strings.Join(parts[:len(parts)-2], "/"),
+	}
+	for i := 0; i < len(parts); i += 2 {
+		switch parts[i] {
+		case "projects":
+			resourceName.ProjectID = parts[i+1]
+		case "organizations":
+			resourceName.OrganizationID = parts[i+1]
+		}
+	}
+	return resourceName, nil
+}
+
+// ValidateID returns an error if id can't be used as a segment of a resource name.
+func ValidateID(id string) error {
+	return validateSegment(id)
+}
+
+// ProjectName is the resource name of a project: projects/{project_id}.
+type ProjectName struct {
+	ProjectID string
+}
+
+// ParseProjectName parses a project resource name.
+func ParseProjectName(name string) (ProjectName, error) {
+	resourceName, err := ParseName(name)
+	if err != nil {
+		return ProjectName{}, err
+	}
+	if resourceName.Collection != "projects" || resourceName.Parent != "" {
+		return ProjectName{}, fmt.Errorf("dt: invalid project name %q: expected projects/{project_id}", name)
+	}
+	return ProjectName{ProjectID: resourceName.ID}, nil
+}
+
+func (n ProjectName) String() string {
+	return "projects/" + n.ProjectID
+}
+
+// Validate returns an error if the project ID can't be used in a resource name.
+func (n ProjectName) Validate() error {
+	return validateIDs("project", n.ProjectID)
+}
+
+// DeviceName is the resource name of a device or an emulated device:
+// projects/{project_id}/devices/{device_id}.
+type DeviceName struct {
+	ProjectID string
+	DeviceID  string
+}
+
+// ParseDeviceName parses a device resource name.
+func ParseDeviceName(name string) (DeviceName, error) {
+	projectID, deviceID, err := parseProjectScopedName(name, "devices", "device")
+	return DeviceName{ProjectID: projectID, DeviceID: deviceID}, err
+}
+
+func (n DeviceName) String() string {
+	return ProjectName{ProjectID: n.ProjectID}.String() + "/devices/" + n.DeviceID
+}
+
+// Validate returns an error if any of the IDs can't be used in a resource name.
+func (n DeviceName) Validate() error {
+	return validateIDs("device", n.ProjectID, n.DeviceID)
+}
+
+// DataConnectorName is the resource name of a data connector:
+// projects/{project_id}/dataconnectors/{data_connector_id}.
+type DataConnectorName struct {
+	ProjectID       string
+	DataConnectorID string
+}
+
+// ParseDataConnectorName parses a data connector resource name.
+func ParseDataConnectorName(name string) (DataConnectorName, error) {
+	projectID, dataConnectorID, err := parseProjectScopedName(name, "dataconnectors", "data connector")
+	return DataConnectorName{ProjectID: projectID, DataConnectorID: dataConnectorID}, err
+}
+
+func (n DataConnectorName) String() string {
+	return ProjectName{ProjectID: n.ProjectID}.String() + "/dataconnectors/" + n.DataConnectorID
+}
+
+// Validate returns an error if any of the IDs can't be used in a resource name.
+func (n DataConnectorName) Validate() error {
+	return validateIDs("data connector", n.ProjectID, n.DataConnectorID)
+}
+
+// RuleName is the resource name of a notification rule:
+// projects/{project_id}/rules/{rule_id}.
+type RuleName struct {
+	ProjectID string
+	RuleID    string
+}
+
+// ParseRuleName parses a notification rule resource name.
+func ParseRuleName(name string) (RuleName, error) {
+	projectID, ruleID, err := parseProjectScopedName(name, "rules", "rule")
+	return RuleName{ProjectID: projectID, RuleID: ruleID}, err
+}
+
+func (n RuleName) String() string {
+	return ProjectName{ProjectID: n.ProjectID}.String() + "/rules/" + n.RuleID
+}
+
+// Validate returns an error if any of the IDs can't be used in a resource name.
+func (n RuleName) Validate() error {
+	return validateIDs("rule", n.ProjectID, n.RuleID)
+}
+
+// MemberName is the resource name of a project member:
+// projects/{project_id}/members/{member_id}.
+type MemberName struct {
+	ProjectID string
+	MemberID  string
+}
+
+// ParseMemberName parses a project member resource name.
+func ParseMemberName(name string) (MemberName, error) {
+	projectID, memberID, err := parseProjectScopedName(name, "members", "member")
+	return MemberName{ProjectID: projectID, MemberID: memberID}, err
+}
+
+func (n MemberName) String() string {
+	return ProjectName{ProjectID: n.ProjectID}.String() + "/members/" + n.MemberID
+}
+
+// Validate returns an error if any of the IDs can't be used in a resource name.
+func (n MemberName) Validate() error {
+	return validateIDs("member", n.ProjectID, n.MemberID)
+}
+
+// RoleBindingName is the resource name of the role a member has in the projects
+// of an organization: organizations/{organization_id}/roles/{role_id}/members/{member_id}.
+type RoleBindingName struct {
+	OrganizationID string
+	RoleID         string
+	MemberID       string
+}
+
+// ParseRoleBindingName parses a role binding resource name.
+func ParseRoleBindingName(name string) (RoleBindingName, error) {
+	resourceName, err := ParseName(name)
+	if err != nil {
+		return RoleBindingName{}, err
+	}
+	parts := strings.Split(name, "/")
+	if len(parts) != 6 || parts[0] != "organizations" || parts[2] != "roles" || parts[4] != "members" {
+		return RoleBindingName{}, fmt.Errorf("dt: invalid role binding name %q: expected organizations/{organization_id}/roles/{role_id}/members/{member_id}", name)
+	}
+	return RoleBindingName{OrganizationID: resourceName.OrganizationID, RoleID: parts[3], MemberID: resourceName.ID}, nil
+}
+
+func (n RoleBindingName) String() string {
+	return "organizations/" + n.OrganizationID + "/roles/" + n.RoleID + "/members/" + n.MemberID
+}
+
+// Validate returns an error if any of the IDs can't be used in a resource name.
+func (n RoleBindingName) Validate() error {
+	return validateIDs("role binding", n.OrganizationID, n.RoleID, n.MemberID)
+}
+
+// parseProjectScopedName parses a resource name on the form
+// projects/{project_id}/{collection}/{id} into the project ID and ID. kind
+// names the resource in errors.
+func parseProjectScopedName(name, collection, kind string) (projectID string, id string, err error) {
+	resourceName, err := ParseName(name)
+	if err != nil {
+		return "", "", err
+	}
+	if resourceName.Collection != collection || resourceName.Parent != "projects/"+resourceName.ProjectID {
+		return "", "", fmt.Errorf("dt: invalid %s name %q: expected projects/{project_id}/%s/{id}", kind, name, collection)
+	}
+	return resourceName.ProjectID, resourceName.ID, nil
+}
+
+// validateIDs validates the IDs that make up the resource name of a kind.
+func validateIDs(kind string, ids ...string) error {
+	for _, id := range ids {
+		if err := validateSegment(id); err != nil {
+			return fmt.Errorf("dt: invalid %s name: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+func validateSegment(segment string) error {
+	switch {
+	case segment == "":
+		return fmt.Errorf("empty segment")
+	case strings.Contains(segment, "/"):
+		return fmt.Errorf("segment %q contains a slash", segment)
+	case strings.ContainsAny(segment, " \t\r\n"):
+		return fmt.Errorf("segment %q contains whitespace", segment)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name    string
+		want    ResourceName
+		wantErr bool
+	}{
+		"project": {
+			name: "projects/p1",
+			want: ResourceName{Collection: "projects", ID: "p1", ProjectID: "p1"},
+		},
+		"device": {
+			name: "projects/p1/devices/d1",
+			want: ResourceName{Collection: "devices", ID: "d1", Parent: "projects/p1", ProjectID: "p1"},
+		},
+		"organization member": {
+			name: "organizations/o1/roles/r1/members/m1",
+			want: ResourceName{Collection: "members", ID: "m1", Parent: "organizations/o1/roles/r1", OrganizationID: "o1"},
+		},
+		"odd number of segments": {name: "projects/p1/devices", wantErr: true},
+		"empty segment":          {name: "projects//devices/d1", wantErr: true},
+		"unknown root":           {name: "things/t1", wantErr: true},
+		"whitespace":             {name: "projects/p 1", wantErr: true},
+		"empty":                  {name: "", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTypedNames(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name  string
+		parse func(string) (fmt.Stringer, error)
+	}{
+		"project":        {name: "projects/p1", parse: stringer(ParseProjectName)},
+		"device":         {name: "projects/p1/devices/d1", parse: stringer(ParseDeviceName)},
+		"data connector": {name: "projects/p1/dataconnectors/c1", parse: stringer(ParseDataConnectorName)},
+		"rule":           {name: "projects/p1/rules/r1", parse: stringer(ParseRuleName)},
+		"member":         {name: "projects/p1/members/m1", parse: stringer(ParseMemberName)},
+		"role binding":   {name: "organizations/o1/roles/project.user/members/m1", parse: stringer(ParseRoleBindingName)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.parse(tt.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tt.name {
+				t.Errorf("expected %q, got %q", tt.name, got.String())
+			}
+
+			// Names of other kinds, or nested below this one, don't parse
+			for _, other := range []string{"projects/p1/things/t1", "organizations/o1/members/m1", tt.name + "/events/e1"} {
+				if _, err := tt.parse(other); err == nil {
+					t.Errorf("expected an error for %q", other)
+				}
+			}
+		})
+	}
+}
+
+func TestTypedNameValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := (RuleName{ProjectID: "p1", RuleID: "r1"}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, name := range []interface{ Validate() error }{
+		ProjectName{},
+		DeviceName{ProjectID: "p1", DeviceID: "d/1"},
+		DataConnectorName{ProjectID: "p 1", DataConnectorID: "c1"},
+		MemberName{ProjectID: "p1"},
+		RoleBindingName{OrganizationID: "o1", MemberID: "m1"},
+	} {
+		if err := name.Validate(); err == nil {
+			t.Errorf("expected an error for %#v", name)
+		}
+	}
+}
+
+// FuzzTypedNames checks that formatting and parsing the typed names round
+// trip, and that every name that parses formats back to the same name.
+func FuzzTypedNames(f *testing.F) {
+	f.Add("p1", "d1")
+	f.Add("c8n5cmpsd1f4ma0p5jt0", "emu-d1gk0ql9c0hsce8kms50")
+	f.Add("-", "a:b")
+	f.Add("", "x/y")
+
+	f.Fuzz(func(t *testing.T, projectID, id string) {
+		names := []interface {
+			fmt.Stringer
+			Validate() error
+		}{
+			ProjectName{ProjectID: projectID},
+			DeviceName{ProjectID: projectID, DeviceID: id},
+			DataConnectorName{ProjectID: projectID, DataConnectorID: id},
+			RuleName{ProjectID: projectID, RuleID: id},
+			MemberName{ProjectID: projectID, MemberID: id},
+		}
+		parsers := []func(string) (fmt.Stringer, error){
+			stringer(ParseProjectName),
+			stringer(ParseDeviceName),
+			stringer(ParseDataConnectorName),
+			stringer(ParseRuleName),
+			stringer(ParseMemberName),
+		}
+
+		for i, name := range names {
+			parsed, err := parsers[i](name.String())
+			if valid := name.Validate() == nil; valid != (err == nil) {
+				t.Fatalf("%#v: Validate() and parsing %q disagree: %v", name, name.String(), err)
+			}
+			if err == nil && parsed != name {
+				t.Errorf("expected %#v, got %#v", name, parsed)
+			}
+		}
+
+		// An arbitrary string that parses must format to itself
+		raw := projectID + "/" + id
+		for _, parse := range parsers {
+			if parsed, err := parse(raw); err == nil && parsed.String() != raw {
+				t.Errorf("parsing %q formats to %q", raw, parsed.String())
+			}
+		}
+	})
+}
+
+// stringer adapts a typed name parser to return a fmt.Stringer.
+func stringer[N fmt.Stringer](parse func(string) (N, error)) func(string) (fmt.Stringer, error) {
+	return func(name string) (fmt.Stringer, error) {
+		n, err := parse(name)
+		return n, err
+	}
+}
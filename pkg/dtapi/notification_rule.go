@@ -1,18 +1,18 @@
 // Copyright (c) HashiCorp, Inc.
 
-package dt
+package dtapi
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 	"sync"
 )
 
 // DISCLAIMER: The Notification Rule API is not released yet and is subject to change.
 
+// ListNotificationRuleResponse is a page of notification rules.
 type ListNotificationRuleResponse struct {
 	NotificationRules []NotificationRule `json:"rules"`
 }
@@ -54,17 +54,20 @@ type NotificationAction struct {
 	SignalTowerConfig    *SignalTowerConfig    `json:"signalTower"`
 }
 
+// SMSConfig configures an SMS action.
 type SMSConfig struct {
 	Recipients []string `json:"recipients"`
 	Body       string   `json:"body"`
 }
 
+// EmailConfig configures an EMAIL action.
 type EmailConfig struct {
 	Recipients []string `json:"recipients"`
 	Subject    string   `json:"subject"`
 	Body       string   `json:"body"`
 }
 
+// CorrigoConfig configures a CORRIGO action, which creates Corrigo work orders.
 type CorrigoConfig struct {
 	AssetID              string `json:"assetId"`
 	TaskID               string `json:"taskId"`
@@ -79,6 +82,8 @@ type CorrigoConfig struct {
 	StudioDashboardURL   string `json:"studioDashboardUrl"`
 }
 
+// ServiceChannelConfig configures a SERVICE_CHANNEL action, which creates
+// ServiceChannel work orders.
 type ServiceChannelConfig struct {
 	StoreID     string `json:"storeId"`
 	AssetTagID  string `json:"assetTagId"`
@@ -86,22 +91,27 @@ type ServiceChannelConfig struct {
 	Description string `json:"description"`
 }
 
+// WebhookConfig configures a WEBHOOK action.
 type WebhookConfig struct {
 	URL             string            `json:"url"`
 	SignatureSecret string            `json:"signatureSecret"`
 	Headers         map[string]string `json:"headers"`
 }
 
+// PhoneCallConfig configures a PHONE_CALL action.
 type PhoneCallConfig struct {
 	Recipients   []string `json:"recipients"`
 	Introduction string   `json:"introduction"`
 	Message      string   `json:"message"`
 }
 
+// SignalTowerConfig configures a SIGNAL_TOWER action on a cloud connector.
 type SignalTowerConfig struct {
 	CloudConnectorName string `json:"cloudConnectorName"`
 }
 
+// Trigger is the condition that triggers a notification rule. Field is the
+// event field, and only the condition for that field is set.
 type Trigger struct {
 	Field        string  `json:"field"`
 	Range        *Range  `json:"range"`
@@ -113,6 +123,7 @@ type Trigger struct {
 	TriggerCount int32   `json:"triggerCount"`
 }
 
+// Range triggers when a value is WITHIN or OUTSIDE the lower and upper bounds.
 type Range struct {
 	Lower  *float64 `json:"lower"`
 	Upper  *float64 `json:"upper"`
@@ -120,26 +131,32 @@ type Range struct {
 	Filter *Filter  `json:"filter"`
 }
 
+// Filter applies a filter to the value before it is compared to the range.
 type Filter struct {
 	ProductEquivalentTemperature *struct{} `json:"productEquivalentTemperature"`
 }
 
+// Schedule limits when a notification rule is active. With Inverse set, the
+// rule is active outside the slots instead.
 type Schedule struct {
 	Timezone string `json:"timezone"`
 	Slots    []Slot `json:"slots"`
 	Inverse  bool   `json:"inverse"`
 }
 
+// Slot is a set of time ranges on some days of the week.
 type Slot struct {
 	DaysOfWeek []string    `json:"days"`
 	TimeRange  []TimeRange `json:"times"`
 }
 
+// TimeRange is a range of time within a day.
 type TimeRange struct {
 	Start TimeOfDay `json:"start"`
 	End   TimeOfDay `json:"end"`
 }
 
+// TimeOfDay is a time of day in the time zone of the schedule.
 type TimeOfDay struct {
 	Hour   int32 `json:"hour"`
 	Minute int32 `json:"minute"`
@@ -174,13 +191,13 @@ func (c *Client) GetNotificationRule(ctx context.Context, name string) (Notifica
 	}
 
 	// If the rule is not in the cache, we need to parse the resource name
-	projectID, _, err := ParseResourceName(name)
+	ruleName, err := ParseRuleName(name)
 	if err != nil {
 		return NotificationRule{}, fmt.Errorf("dt: failed to parse resource name: %w", err)
 	}
 
 	// make a list request to get all rules in the project and populate the cache.
-	response, err := c.listNotificationRules(ctx, projectID)
+	response, err := c.listNotificationRules(ctx, ruleName.ProjectID)
 	if err != nil {
 		return NotificationRule{}, fmt.Errorf("dt: failed to list notification rules: %w", err)
 	}
@@ -197,8 +214,20 @@ func (c *Client) GetNotificationRule(ctx context.Context, name string) (Notifica
 	return rule, nil
 }
 
+// ListNotificationRules lists the notification rules in a project.
+func (c *Client) ListNotificationRules(ctx context.Context, projectID string) ([]NotificationRule, error) {
+	response, err := c.listNotificationRules(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range response.NotificationRules {
+		c.rulesCache.setRule(rule)
+	}
+	return response.NotificationRules, nil
+}
+
 func (c *Client) listNotificationRules(ctx context.Context, projectID string) (ListNotificationRuleResponse, error) {
-	url := fmt.Sprintf("%s/v2alpha/projects/%s/rules", strings.TrimSuffix(c.URL, "/"), projectID)
+	url := c.apiURL("v2alpha", "projects", projectID, "rules")
 	responseBody, err := c.DoRequest(ctx, http.MethodGet, url, nil, nil)
 	if err != nil {
 		return ListNotificationRuleResponse{}, fmt.Errorf("dt: failed to list notification rules: %w", err)
@@ -214,7 +243,7 @@ func (c *Client) listNotificationRules(ctx context.Context, projectID string) (L
 
 // CreateNotificationRule creates a new notification rule.
 func (c *Client) CreateNotificationRule(ctx context.Context, projectID string, rule NotificationRule) (NotificationRule, error) {
-	url := fmt.Sprintf("%s/v2alpha/projects/%s/rules", strings.TrimSuffix(c.URL, "/"), projectID)
+	url := c.apiURL("v2alpha", "projects", projectID, "rules")
 
 	body, err := json.Marshal(rule)
 	if err != nil {
@@ -236,12 +265,12 @@ func (c *Client) CreateNotificationRule(ctx context.Context, projectID string, r
 
 // UpdateNotificationRule updates an existing notification rule.
 func (c *Client) UpdateNotificationRule(ctx context.Context, rule NotificationRule) (NotificationRule, error) {
-	projectID, ruleID, err := ParseResourceName(rule.Name)
+	ruleName, err := ParseRuleName(rule.Name)
 	if err != nil {
 		return NotificationRule{}, fmt.Errorf("dt: failed to parse resource name: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/v2alpha/projects/%s/rules/%s", strings.TrimSuffix(c.URL, "/"), projectID, ruleID)
+	url := c.apiURL("v2alpha", "projects", ruleName.ProjectID, "rules", ruleName.RuleID)
 
 	body, err := json.Marshal(rule)
 	if err != nil {
@@ -261,14 +290,33 @@ func (c *Client) UpdateNotificationRule(ctx context.Context, rule NotificationRu
 	return updatedRule, nil
 }
 
+// SetNotificationRuleEnabled enables or disables a notification rule, leaving the
+// rest of the rule unchanged.
+func (c *Client) SetNotificationRuleEnabled(ctx context.Context, name string, enabled bool) (NotificationRule, error) {
+	rule, err := c.GetNotificationRule(ctx, name)
+	if err != nil {
+		return NotificationRule{}, err
+	}
+
+	rule.Enabled = enabled
+	updatedRule, err := c.UpdateNotificationRule(ctx, rule)
+	if err != nil {
+		return NotificationRule{}, err
+	}
+	// keep the cache in sync, so that later reads see the change
+	c.rulesCache.setRule(updatedRule)
+
+	return updatedRule, nil
+}
+
 // DeleteNotificationRule deletes a notification rule.
 func (c *Client) DeleteNotificationRule(ctx context.Context, name string) error {
-	projectID, ruleID, err := ParseResourceName(name)
+	ruleName, err := ParseRuleName(name)
 	if err != nil {
 		return fmt.Errorf("dt: failed to parse resource name: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/v2alpha/projects/%s/rules/%s", strings.TrimSuffix(c.URL, "/"), projectID, ruleID)
+	url := c.apiURL("v2alpha", "projects", ruleName.ProjectID, "rules", ruleName.RuleID)
 	_, err = c.DoRequest(ctx, http.MethodDelete, url, nil, nil)
 	if err != nil {
 		return fmt.Errorf("dt: failed to delete notification rule: %w", err)
@@ -276,13 +324,3 @@ func (c *Client) DeleteNotificationRule(ctx context.Context, name string) error
 
 	return nil
 }
-
-// ParseResourceName is a helper function to parse the resource name projects/{projectID}/rules/{ruleID}
-// into projectID and notificationRuleID.
-func ParseResourceName(name string) (string, string, error) {
-	parts := strings.Split(name, "/")
-	if len(parts) != 4 {
-		return "", "", fmt.Errorf("dt: invalid resource name: %s", name)
-	}
-	return parts[1], parts[3], nil
-}
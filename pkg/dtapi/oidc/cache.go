@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheExpiryMargin is how long before expiry a cached token is considered stale.
+// Tokens are shared between provider processes that may run for a while, so a
+// token that is about to expire is not worth reusing.
+const cacheExpiryMargin = 5 * time.Minute
+
+// fileCache persists access tokens on disk so they can be shared between provider processes.
+type fileCache struct {
+	dir string
+}
+
+type cachedToken struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// path returns the cache file for the given token endpoint and key ID.
+func (c *fileCache) path(tokenEndpoint, keyID string) string {
+	sum := sha256.Sum256([]byte(tokenEndpoint + "\x00" + keyID))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// get returns the cached token if it exists and is not about to expire.
+func (c *fileCache) get(tokenEndpoint, keyID string) (cachedToken, bool) {
+	path := c.path(tokenEndpoint, keyID)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return cachedToken{}, false
+	}
+	// Ignore cache files that are readable by anyone but the owner.
+	if info.Mode().Perm()&0o077 != 0 {
+		return cachedToken{}, false
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cachedToken{}, false
+	}
+
+	var token cachedToken
+	if err := json.Unmarshal(content, &token); err != nil {
+		return cachedToken{}, false
+	}
+	if token.AccessToken == "" || time.Until(token.Expiry) < cacheExpiryMargin {
+		return cachedToken{}, false
+	}
+
+	return token, true
+}
+
+// set writes the token to the cache. The file is written to a temporary file
+// first and renamed, so concurrent provider processes never read a partial token.
+func (c *fileCache) set(tokenEndpoint, keyID string, token cachedToken) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("oidc: failed to create token cache directory: %w", err)
+	}
+
+	content, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("oidc: failed to marshal cached token: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("oidc: failed to create token cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	// CreateTemp uses 0600, but be explicit in case the umask or platform differs.
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("oidc: failed to set token cache file permissions: %w", err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("oidc: failed to write token cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("oidc: failed to write token cache file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path(tokenEndpoint, keyID)); err != nil {
+		return fmt.Errorf("oidc: failed to write token cache file: %w", err)
+	}
+	return nil
+}
+
+// DefaultCacheDir returns the default directory for the on-disk token cache.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("oidc: failed to find user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "dt", "tokens"), nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	t.Parallel()
+
+	cache := &fileCache{dir: t.TempDir()}
+	token := cachedToken{
+		AccessToken: "access-token",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour).Round(0),
+	}
+
+	if _, ok := cache.get("https://identity.example.com/token", "key"); ok {
+		t.Fatal("expected an empty cache")
+	}
+
+	if err := cache.set("https://identity.example.com/token", "key", token); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, ok := cache.get("https://identity.example.com/token", "key")
+	if !ok {
+		t.Fatal("expected a cached token")
+	}
+	if got.AccessToken != token.AccessToken || got.TokenType != token.TokenType || !got.Expiry.Equal(token.Expiry) {
+		t.Errorf("expected %+v, got %+v", token, got)
+	}
+
+	// tokens are keyed by token endpoint and key ID
+	if _, ok := cache.get("https://identity.example.com/token", "other-key"); ok {
+		t.Error("expected no token for another key ID")
+	}
+	if _, ok := cache.get("https://identity.other.com/token", "key"); ok {
+		t.Error("expected no token for another token endpoint")
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(cache.path("https://identity.example.com/token", "key"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o600 {
+			t.Errorf("expected cache file permissions 0600, got %o", perm)
+		}
+	}
+}
+
+func TestFileCacheExpiry(t *testing.T) {
+	t.Parallel()
+
+	cache := &fileCache{dir: t.TempDir()}
+	err := cache.set("endpoint", "key", cachedToken{
+		AccessToken: "access-token",
+		Expiry:      time.Now().Add(cacheExpiryMargin / 2),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := cache.get("endpoint", "key"); ok {
+		t.Error("expected a token that is about to expire to be ignored")
+	}
+}
+
+func TestFileCacheIgnoresReadableFiles(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not enforced on windows")
+	}
+
+	cache := &fileCache{dir: t.TempDir()}
+	err := cache.set("endpoint", "key", cachedToken{
+		AccessToken: "access-token",
+		Expiry:      time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.Chmod(cache.path("endpoint", "key"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := cache.get("endpoint", "key"); ok {
+		t.Error("expected a world readable cache file to be ignored")
+	}
+}
+
+func TestGetTokenSharesOnDiskCache(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		TokenEndpoint: server.URL,
+		ClientID:      "key",
+		ClientSecret:  "secret",
+		Email:         "service-account@example.com",
+		TokenCacheDir: t.TempDir(),
+	}
+
+	// Simulate separate provider processes with separate clients.
+	for i := 0; i < 3; i++ {
+		token, err := NewClient(cfg).GetToken(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token.AccessToken != "access-token" {
+			t.Errorf("unexpected access token: %s", token.AccessToken)
+		}
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 token request, got %d", got)
+	}
+}
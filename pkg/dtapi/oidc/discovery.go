@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Metadata is the subset of the OpenID provider metadata used by the provider.
+// See https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type Metadata struct {
+	// The issuer identifier of the OIDC provider.
+	Issuer string `json:"issuer"`
+	// The URL of the token endpoint, used to exchange the JWT assertion for an access token.
+	TokenEndpoint string `json:"token_endpoint"`
+	// The URL of the authorization endpoint.
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	// The URL of the JSON Web Key Set of the OIDC provider.
+	JWKSURI string `json:"jwks_uri"`
+	// The signing algorithms supported for client authentication at the token endpoint.
+	TokenEndpointAuthSigningAlgValuesSupported []string `json:"token_endpoint_auth_signing_alg_values_supported"`
+}
+
+// metadataCache caches the discovered metadata per issuer for the lifetime of the process.
+var metadataCache = struct {
+	metadata map[string]Metadata
+	mu       sync.Mutex
+}{
+	metadata: make(map[string]Metadata),
+}
+
+// Discover fetches the OpenID provider metadata from the issuer's
+// /.well-known/openid-configuration document. The result is cached per issuer.
+func Discover(ctx context.Context, issuer string) (Metadata, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	metadataCache.mu.Lock()
+	defer metadataCache.mu.Unlock()
+	if metadata, ok := metadataCache.metadata[issuer]; ok {
+		tflog.Debug(ctx, "using cached OIDC provider metadata")
+		return metadata, nil
+	}
+
+	url := issuer + "/.well-known/openid-configuration"
+	ctx = tflog.SetField(ctx, "url", url)
+	ctx = tflog.SetField(ctx, "method", http.MethodGet)
+	tflog.Debug(ctx, "fetching OIDC provider metadata")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("oidc: failed to create discovery request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// Set a 3 second timeout in case the server can't be reached.
+	httpClient := &http.Client{Timeout: time.Second * 3}
+	response, err := httpClient.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("oidc: failed to fetch provider metadata: %w", err)
+	}
+	defer response.Body.Close()
+
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("oidc: failed to read provider metadata: %w, status: %d", err, response.StatusCode)
+	}
+	if response.StatusCode != http.StatusOK {
+		return Metadata{}, fmt.Errorf("oidc: failed to fetch provider metadata from %s: HTTP error: %d: %s", url, response.StatusCode, string(bodyBytes))
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(bodyBytes, &metadata); err != nil {
+		return Metadata{}, fmt.Errorf("oidc: failed to unmarshal provider metadata: %w", err)
+	}
+
+	// The issuer in the metadata must match the issuer it was fetched from.
+	if strings.TrimSuffix(metadata.Issuer, "/") != issuer {
+		return Metadata{}, fmt.Errorf("oidc: provider metadata issuer %q does not match the configured issuer %q", metadata.Issuer, issuer)
+	}
+	if metadata.TokenEndpoint == "" {
+		return Metadata{}, fmt.Errorf("oidc: provider metadata from %s does not contain a token endpoint", url)
+	}
+
+	metadataCache.metadata[issuer] = metadata
+	return metadata, nil
+}
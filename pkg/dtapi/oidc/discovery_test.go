@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		requests.Add(1)
+		fmt.Fprintf(w, `{"issuer":%q,"token_endpoint":%q}`, server.URL, server.URL+"/oauth2/token")
+	}))
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		// a trailing slash on the issuer is ignored
+		metadata, err := Discover(context.Background(), server.URL+"/")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if metadata.TokenEndpoint != server.URL+"/oauth2/token" {
+			t.Errorf("unexpected token endpoint: %s", metadata.TokenEndpoint)
+		}
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected the metadata to be fetched once, got %d requests", got)
+	}
+}
+
+func TestDiscoverErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]http.HandlerFunc{
+		"issuer mismatch": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"issuer":"https://other.example.com","token_endpoint":"https://other.example.com/token"}`)
+		},
+		"missing token endpoint": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"issuer":"http://%s"}`, r.Host)
+		},
+		"not found": http.NotFound,
+		"invalid json": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `not json`)
+		},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			if _, err := Discover(context.Background(), server.URL); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestGetTokenDiscoversTokenEndpoint(t *testing.T) {
+	t.Parallel()
+
+	var audience string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			fmt.Fprintf(w, `{"issuer":%q,"token_endpoint":%q}`, server.URL, server.URL+"/oauth2/token")
+		case "/oauth2/token":
+			token, _, err := jwt.NewParser().ParseUnverified(r.FormValue("assertion"), &jwt.RegisteredClaims{})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			aud, _ := token.Claims.GetAudience()
+			if len(aud) == 1 {
+				audience = aud[0]
+			}
+			fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{
+		Issuer:       server.URL,
+		ClientID:     "key",
+		ClientSecret: "secret",
+		Email:        "service-account@example.com",
+	})
+	if _, err := client.GetToken(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if audience != server.URL+"/oauth2/token" {
+		t.Errorf("expected the discovered token endpoint as audience, got %q", audience)
+	}
+}
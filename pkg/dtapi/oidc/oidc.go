@@ -1,5 +1,7 @@
 // Copyright (c) HashiCorp, Inc.
 
+// Package oidc gets access tokens for DT service accounts from an OIDC token
+// endpoint, signing the token request with the key secret or a private key.
 package oidc
 
 import (
@@ -20,15 +22,25 @@ import (
 type Client struct {
 	// Token endpoint for the OIDC provider.
 	tokenEndpoint string
+	// Issuer used to discover the token endpoint when it is not set.
+	issuer string
+	// Guards the token endpoint while it is being discovered.
+	endpointMu sync.Mutex
 	// The client ID used to authenticate with the OIDC provider.
 	clientID string
 	// The client secret used to authenticate with the OIDC provider.
 	clientSecret string
 	// The email address used to authenticate with the OIDC provider.
 	email string
+	// Optional asymmetric key used to sign the JWT instead of the client secret.
+	privateKey *signingKey
+	// Error from parsing the private key, returned when creating the JWT.
+	privateKeyErr error
 
 	// The access token used to access the Disruptive REST API.
 	token *Token
+	// Optional on-disk token cache shared between provider processes.
+	cache *fileCache
 }
 
 type Token struct {
@@ -62,28 +74,72 @@ func (t *Token) isExpired() bool {
 type Config struct {
 	// Token endpoint for the OIDC provider.
 	TokenEndpoint string
+	// Issuer of the OIDC provider. Used to discover the token endpoint
+	// when TokenEndpoint is not set.
+	Issuer string
 	// The client ID used to authenticate with the OIDC provider.
 	ClientID string
 	// The client secret used to authenticate with the OIDC provider.
 	ClientSecret string
 	// The email address used to authenticate with the OIDC provider.
 	Email string
+	// Directory for the on-disk token cache. The cache is disabled when empty.
+	TokenCacheDir string
+	// PEM encoded RSA or EC private key. When set, the JWT is signed with RS256
+	// or ES256 using this key instead of HS256 using the client secret.
+	PrivateKey []byte
 }
 
 func NewClient(cfg Config) *Client {
-	return &Client{
+	client := &Client{
 		tokenEndpoint: cfg.TokenEndpoint,
+		issuer:        cfg.Issuer,
 		clientID:      cfg.ClientID,
 		clientSecret:  cfg.ClientSecret,
 		email:         cfg.Email,
 		token:         &Token{},
 	}
+	if cfg.TokenCacheDir != "" {
+		client.cache = &fileCache{dir: cfg.TokenCacheDir}
+	}
+	if len(cfg.PrivateKey) > 0 {
+		client.privateKey, client.privateKeyErr = parsePrivateKey(cfg.PrivateKey)
+	}
+	return client
+}
+
+// resolveTokenEndpoint returns the token endpoint, discovering it from the
+// issuer metadata if it was not configured.
+func (c *Client) resolveTokenEndpoint(ctx context.Context) (string, error) {
+	c.endpointMu.Lock()
+	defer c.endpointMu.Unlock()
+	if c.tokenEndpoint != "" || c.issuer == "" {
+		return c.tokenEndpoint, nil
+	}
+
+	metadata, err := Discover(ctx, c.issuer)
+	if err != nil {
+		return "", err
+	}
+	c.tokenEndpoint = metadata.TokenEndpoint
+	return c.tokenEndpoint, nil
 }
 
 func (c *Client) createJWT() (string, error) {
+	// Sign with the key secret, unless an asymmetric private key is configured.
+	var method jwt.SigningMethod = jwt.SigningMethodHS256
+	var key interface{} = []byte(c.clientSecret)
+	if c.privateKeyErr != nil {
+		return "", c.privateKeyErr
+	}
+	if c.privateKey != nil {
+		method = c.privateKey.method
+		key = c.privateKey.key
+	}
+
 	// Construct the JWT header.
 	jwtHeader := map[string]interface{}{
-		"alg": "HS256",
+		"alg": method.Alg(),
 		"kid": c.clientID,
 	}
 
@@ -96,10 +152,10 @@ func (c *Client) createJWT() (string, error) {
 		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
 	}
 
-	// Sign and encode JWT with the secret.
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtPayload)
+	// Sign and encode JWT with the secret or private key.
+	token := jwt.NewWithClaims(method, jwtPayload)
 	token.Header = jwtHeader
-	encodedJwt, err := token.SignedString([]byte(c.clientSecret))
+	encodedJwt, err := token.SignedString(key)
 	if err != nil {
 		return "", err
 	}
@@ -118,6 +174,24 @@ func (c *Client) GetToken(ctx context.Context) (*AuthResponse, error) {
 		}, nil
 	}
 
+	// The token endpoint is also the audience of the JWT, so make sure it is known.
+	if _, err := c.resolveTokenEndpoint(ctx); err != nil {
+		return nil, err
+	}
+
+	// Check if another provider process has already cached a valid token on disk.
+	if c.cache != nil {
+		if cached, ok := c.cache.get(c.tokenEndpoint, c.clientID); ok {
+			tflog.Debug(ctx, "using token from on-disk cache")
+			c.token.set(cached.AccessToken, cached.TokenType, cached.Expiry)
+			return &AuthResponse{
+				AccessToken: cached.AccessToken,
+				TokenType:   cached.TokenType,
+				ExpiresIn:   int(time.Until(cached.Expiry).Seconds()),
+			}, nil
+		}
+	}
+
 	jwt, err := c.createJWT()
 	if err != nil {
 		return nil, fmt.Errorf("oidc: failed to create JWT: %w", err)
@@ -129,10 +203,12 @@ func (c *Client) GetToken(ctx context.Context) (*AuthResponse, error) {
 		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
 	}.Encode()
 
-	ctx = tflog.SetField(ctx, "jwt", jwt)
+	// The signed JWT and the access token are credentials, so only the key
+	// ID of the JWT is logged, and the response body only when the request
+	// fails.
+	ctx = tflog.SetField(ctx, "kid", c.clientID)
 	ctx = tflog.SetField(ctx, "token_endpoint", c.tokenEndpoint)
 	ctx = tflog.SetField(ctx, "method", http.MethodPost)
-	ctx = tflog.SetField(ctx, "body", reqData)
 
 	tflog.Debug(ctx, "sending request to OIDC provider")
 
@@ -158,11 +234,13 @@ func (c *Client) GetToken(ctx context.Context) (*AuthResponse, error) {
 	if err != nil {
 		return nil, fmt.Errorf("oidc: failed to read response body: %w, status: %d", err, response.StatusCode)
 	}
-	ctx = tflog.SetField(ctx, "body", string(bodyBytes))
 	ctx = tflog.SetField(ctx, "status_code", response.StatusCode)
 	if response.StatusCode != http.StatusOK {
-		tflog.Debug(ctx, "received non-200 status code from DT API")
-		return nil, fmt.Errorf("HTTP error: %d: %s", response.StatusCode, string(bodyBytes))
+		tflog.Debug(ctx, "received non-200 status code from DT API", map[string]interface{}{"body": string(bodyBytes)})
+		return nil, &HTTPError{
+			StatusCode: response.StatusCode,
+			Body:       string(bodyBytes),
+		}
 	}
 
 	// Decode the response body to an AuthResponse.
@@ -176,10 +254,33 @@ func (c *Client) GetToken(ctx context.Context) (*AuthResponse, error) {
 	expiry := time.Now().Add(time.Duration(authResponse.ExpiresIn) * time.Second)
 	c.token.set(authResponse.AccessToken, authResponse.TokenType, expiry)
 
+	// Share the token with other provider processes. A failure to write the
+	// cache is not fatal, the token is still valid for this process.
+	if c.cache != nil {
+		err = c.cache.set(c.tokenEndpoint, c.clientID, cachedToken{
+			AccessToken: authResponse.AccessToken,
+			TokenType:   authResponse.TokenType,
+			Expiry:      expiry,
+		})
+		if err != nil {
+			tflog.Warn(ctx, "failed to write token to on-disk cache", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
 	return authResponse, nil
 
 }
 
+// HTTPError is returned when the OIDC provider rejects the token request.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP error: %d: %s", e.StatusCode, e.Body)
+}
+
 type AuthResponse struct {
 	// The access token used to access the Disruptive REST API.
 	AccessToken string `json:"access_token"`
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestGetTokenLogging(t *testing.T) {
+	t.Parallel()
+
+	var assertion string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertion = r.FormValue("assertion")
+		fmt.Fprint(w, `{"access_token":"secret-access-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := NewClient(Config{
+		TokenEndpoint: server.URL,
+		ClientID:      "key-id",
+		ClientSecret:  "secret",
+		Email:         "service-account@example.com",
+	})
+	if _, err := client.GetToken(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs := output.String()
+	if assertion == "" || strings.Contains(logs, assertion) {
+		t.Errorf("expected the JWT assertion not to be logged, got: %s", logs)
+	}
+	if strings.Contains(logs, "secret-access-token") {
+		t.Errorf("expected the access token not to be logged, got: %s", logs)
+	}
+	if !strings.Contains(logs, `"kid":"key-id"`) {
+		t.Errorf("expected the key ID to be logged, got: %s", logs)
+	}
+}
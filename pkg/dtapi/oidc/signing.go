@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// signingKey is an asymmetric key used to sign the JWT assertion instead of the key secret.
+type signingKey struct {
+	method jwt.SigningMethod
+	key    interface{}
+}
+
+// parsePrivateKey parses a PEM encoded RSA or EC private key, in either PKCS #1,
+// SEC 1 or PKCS #8 form, and picks the matching signing algorithm.
+func parsePrivateKey(pemBytes []byte) (*signingKey, error) {
+	rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+	if err == nil {
+		return &signingKey{method: jwt.SigningMethodRS256, key: rsaKey}, nil
+	}
+	if errors.Is(err, jwt.ErrKeyMustBePEMEncoded) {
+		return nil, fmt.Errorf("oidc: private key must be PEM encoded")
+	}
+
+	ecKey, err := jwt.ParseECPrivateKeyFromPEM(pemBytes)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: private key must be an RSA or EC private key")
+	}
+	return ecSigningKey(ecKey)
+}
+
+func ecSigningKey(key *ecdsa.PrivateKey) (*signingKey, error) {
+	switch key.Curve {
+	case elliptic.P256():
+		return &signingKey{method: jwt.SigningMethodES256, key: key}, nil
+	case elliptic.P384():
+		return &signingKey{method: jwt.SigningMethodES384, key: key}, nil
+	case elliptic.P521():
+		return &signingKey{method: jwt.SigningMethodES512, key: key}, nil
+	default:
+		return nil, fmt.Errorf("oidc: unsupported elliptic curve: %s", key.Curve.Params().Name)
+	}
+}
+
+// ValidatePrivateKey checks that the PEM encoded private key can be used to sign the JWT assertion.
+func ValidatePrivateKey(pemBytes []byte) error {
+	_, err := parsePrivateKey(pemBytes)
+	return err
+}
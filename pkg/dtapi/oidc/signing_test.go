@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+func TestCreateJWTSigningMethods(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("failed to marshal EC key: %v", err)
+	}
+	pkcs8DER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("failed to marshal PKCS #8 key: %v", err)
+	}
+
+	tests := map[string]struct {
+		privateKey []byte
+		alg        string
+		verifyKey  interface{}
+	}{
+		"key secret": {
+			alg:       "HS256",
+			verifyKey: []byte("secret"),
+		},
+		"rsa pkcs1": {
+			privateKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			alg:        "RS256",
+			verifyKey:  rsaKey.Public(),
+		},
+		"ec sec1": {
+			privateKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+			alg:        "ES256",
+			verifyKey:  ecKey.Public(),
+		},
+		"ec pkcs8": {
+			privateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8DER}),
+			alg:        "ES256",
+			verifyKey:  ecKey.Public(),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := NewClient(Config{
+				TokenEndpoint: "https://identity.example.com/oauth2/token",
+				ClientID:      "key-id",
+				ClientSecret:  "secret",
+				Email:         "service-account@example.com",
+				PrivateKey:    tt.privateKey,
+			})
+
+			encoded, err := client.createJWT()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			token, err := jwt.Parse(encoded, func(token *jwt.Token) (interface{}, error) {
+				return tt.verifyKey, nil
+			}, jwt.WithValidMethods([]string{tt.alg}), jwt.WithAudience("https://identity.example.com/oauth2/token"))
+			if err != nil {
+				t.Fatalf("failed to verify JWT: %v", err)
+			}
+			if kid := token.Header["kid"]; kid != "key-id" {
+				t.Errorf("expected kid key-id, got %v", kid)
+			}
+			if issuer, _ := token.Claims.GetIssuer(); issuer != "service-account@example.com" {
+				t.Errorf("expected issuer service-account@example.com, got %s", issuer)
+			}
+		})
+	}
+}
+
+func TestValidatePrivateKey(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	invalid := map[string][]byte{
+		"not pem":           []byte("not a key"),
+		"not a key":         pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")}),
+		"unsupported type":  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: mustMarshalPKCS8(t, ed25519Key(t))}),
+		"unsupported curve": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: mustMarshalPKCS8(t, ecKey)}),
+	}
+
+	for name, key := range invalid {
+		if err := ValidatePrivateKey(key); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// an invalid key is reported when the JWT is created
+	client := NewClient(Config{PrivateKey: []byte("not a key")})
+	if _, err := client.createJWT(); err == nil {
+		t.Error("expected an error when creating a JWT with an invalid key")
+	}
+}
+
+func ed25519Key(t *testing.T) crypto.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	return key
+}
+
+func mustMarshalPKCS8(t *testing.T, key crypto.PrivateKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return der
+}
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/disruptive-technologies/terraform-provider-dt/pkg/dtapi/oidc"
+)
+
+// Option configures a Client created by New.
+type Option func(*options) error
+
+type options struct {
+	url         string
+	emulatorURL string
+	oidc        oidc.Config
+	httpClient  *http.Client
+	userAgent   string
+	readOnly    bool
+}
+
+// New returns a client for the DT API. Without options the client uses the
+// endpoints of the production environment, and it needs one of
+// WithServiceAccountKey or WithPrivateKey to authenticate. Options are
+// applied in order, so WithURL after WithEnvironment overrides the preset.
+//
+// New returns an error if an option is given an invalid value. Credentials
+// are not checked until the first request; use ValidateCredentials to check
+// them up front.
+func New(opts ...Option) (*Client, error) {
+	production := Environments[EnvironmentProduction]
+	o := &options{
+		url:         production.URL,
+		emulatorURL: production.EmulatorURL,
+		oidc:        oidc.Config{TokenEndpoint: production.TokenEndpoint},
+		httpClient:  http.DefaultClient,
+		userAgent:   fmt.Sprintf("dtapi-go (%s)", runtime.Version()),
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Client{
+		baseURL:         o.url,
+		emulatorBaseURL: o.emulatorURL,
+		httpClient:      o.httpClient,
+		oidc:            oidc.NewClient(o.oidc),
+		userAgent:       o.userAgent,
+		readOnly:        o.readOnly,
+		retryAfter: &retryAfter{
+			t:  time.Now(),
+			mu: sync.RWMutex{},
+		},
+		rulesCache: &rulesCache{
+			notificationRules: make(map[string]NotificationRule),
+			mu:                sync.RWMutex{},
+		},
+		projectCache: &projectCache{
+			projects: make(map[string]Project),
+			mu:       sync.RWMutex{},
+		},
+	}, nil
+}
+
+// WithEnvironment sets the API, emulator and token endpoints to the preset of
+// a DT environment, such as EnvironmentProduction.
+func WithEnvironment(name string) Option {
+	return func(o *options) error {
+		env, ok := Environments[name]
+		if !ok {
+			return fmt.Errorf("dt: unknown environment %q, expected %s", name, EnvironmentProduction)
+		}
+		o.url = env.URL
+		o.emulatorURL = env.EmulatorURL
+		o.oidc.TokenEndpoint = env.TokenEndpoint
+		o.oidc.Issuer = ""
+		return nil
+	}
+}
+
+// WithURL sets the base URL of the DT API, such as
+// https://api.disruptive-technologies.com.
+func WithURL(rawURL string) Option {
+	return func(o *options) error {
+		if err := validateURL("URL", rawURL); err != nil {
+			return err
+		}
+		o.url = rawURL
+		return nil
+	}
+}
+
+// WithEmulatorURL sets the base URL of the DT emulator API, such as
+// https://emulator.disruptive-technologies.com.
+func WithEmulatorURL(rawURL string) Option {
+	return func(o *options) error {
+		if err := validateURL("emulator URL", rawURL); err != nil {
+			return err
+		}
+		o.emulatorURL = rawURL
+		return nil
+	}
+}
+
+// WithTokenEndpoint sets the OIDC token endpoint access tokens are requested
+// from.
+func WithTokenEndpoint(rawURL string) Option {
+	return func(o *options) error {
+		if err := validateURL("token endpoint", rawURL); err != nil {
+			return err
+		}
+		o.oidc.TokenEndpoint = rawURL
+		o.oidc.Issuer = ""
+		return nil
+	}
+}
+
+// WithIssuer discovers the token endpoint from the metadata of the OIDC
+// issuer, such as https://identity.disruptive-technologies.com, on the first
+// request instead of using a fixed token endpoint.
+func WithIssuer(rawURL string) Option {
+	return func(o *options) error {
+		if err := validateURL("issuer", rawURL); err != nil {
+			return err
+		}
+		o.oidc.Issuer = rawURL
+		o.oidc.TokenEndpoint = ""
+		return nil
+	}
+}
+
+// WithServiceAccountKey authenticates with the ID and secret of a service
+// account key, and the email of the service account.
+func WithServiceAccountKey(keyID, secret, email string) Option {
+	return func(o *options) error {
+		if keyID == "" || secret == "" || email == "" {
+			return errors.New("dt: service account key ID, secret and email must all be set")
+		}
+		o.oidc.ClientID = keyID
+		o.oidc.ClientSecret = secret
+		o.oidc.Email = email
+		o.oidc.PrivateKey = nil
+		return nil
+	}
+}
+
+// WithPrivateKey authenticates with a service account key backed by a PEM
+// encoded RSA or EC private key. Token requests are signed with RS256 or
+// ES256 instead of the key secret.
+func WithPrivateKey(keyID string, privateKey []byte, email string) Option {
+	return func(o *options) error {
+		if keyID == "" || email == "" {
+			return errors.New("dt: service account key ID and email must both be set")
+		}
+		if err := oidc.ValidatePrivateKey(privateKey); err != nil {
+			return fmt.Errorf("dt: invalid private key: %w", err)
+		}
+		o.oidc.ClientID = keyID
+		o.oidc.ClientSecret = ""
+		o.oidc.Email = email
+		o.oidc.PrivateKey = privateKey
+		return nil
+	}
+}
+
+// WithTokenCacheDir caches access tokens in dir, so that processes using the
+// same service account key share tokens. oidc.DefaultCacheDir returns the
+// directory the provider uses.
+func WithTokenCacheDir(dir string) Option {
+	return func(o *options) error {
+		o.oidc.TokenCacheDir = dir
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client requests to the DT API are sent with.
+// It defaults to http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) error {
+		if httpClient == nil {
+			return errors.New("dt: HTTP client must not be nil")
+		}
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests to the DT API.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) error {
+		o.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithReadOnly makes the client refuse every request that isn't a GET with
+// ErrReadOnly, such as for audits.
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) error {
+		o.readOnly = readOnly
+		return nil
+	}
+}
+
+// validateURL checks that rawURL is an absolute https URL. Plain http is only
+// accepted for localhost, such as for a local emulator or test server.
+func validateURL(setting, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("dt: invalid %s %q: %w", setting, rawURL, err)
+	}
+	if parsed.Host == "" || (parsed.Scheme != "https" && (parsed.Scheme != "http" || !isLocalhost(parsed.Hostname()))) {
+		return fmt.Errorf("dt: invalid %s %q: must be an absolute https URL, or an http URL of localhost", setting, rawURL)
+	}
+	return nil
+}
+
+// isLocalhost reports whether host is localhost or a loopback address.
+func isLocalhost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	production := Environments[EnvironmentProduction]
+	httpClient := &http.Client{}
+
+	tests := map[string]struct {
+		opts    []Option
+		wantErr bool
+		check   func(t *testing.T, client *Client)
+	}{
+		"defaults to production": {
+			check: func(t *testing.T, client *Client) {
+				if client.baseURL != production.URL || client.emulatorBaseURL != production.EmulatorURL {
+					t.Errorf("expected the production endpoints, got %q and %q", client.baseURL, client.emulatorBaseURL)
+				}
+				if client.httpClient != http.DefaultClient || client.ReadOnly() {
+					t.Errorf("unexpected defaults: %+v", client)
+				}
+			},
+		},
+		"environment": {
+			opts: []Option{WithURL("https://api.example.com"), WithEnvironment(EnvironmentProduction)},
+			check: func(t *testing.T, client *Client) {
+				if client.baseURL != production.URL || client.emulatorBaseURL != production.EmulatorURL {
+					t.Errorf("expected the production endpoints, got %q and %q", client.baseURL, client.emulatorBaseURL)
+				}
+			},
+		},
+		"later options override the environment": {
+			opts: []Option{WithEnvironment(EnvironmentProduction), WithURL("http://localhost:8080")},
+			check: func(t *testing.T, client *Client) {
+				if client.baseURL != "http://localhost:8080" || client.emulatorBaseURL != production.EmulatorURL {
+					t.Errorf("unexpected endpoints %q and %q", client.baseURL, client.emulatorBaseURL)
+				}
+			},
+		},
+		"options": {
+			opts: []Option{WithHTTPClient(httpClient), WithUserAgent("backfill/1.0"), WithReadOnly(true)},
+			check: func(t *testing.T, client *Client) {
+				if client.httpClient != httpClient || client.userAgent != "backfill/1.0" || !client.ReadOnly() {
+					t.Errorf("options not applied: %+v", client)
+				}
+			},
+		},
+		"unknown environment":    {opts: []Option{WithEnvironment(EnvironmentCustom)}, wantErr: true},
+		"relative URL":           {opts: []Option{WithURL("api.disruptive-technologies.com")}, wantErr: true},
+		"invalid emulator URL":   {opts: []Option{WithEmulatorURL("ftp://emulator.example.com")}, wantErr: true},
+		"http URL":               {opts: []Option{WithURL("http://api.example.com")}, wantErr: true},
+		"invalid token endpoint": {opts: []Option{WithTokenEndpoint("")}, wantErr: true},
+		"invalid issuer":         {opts: []Option{WithIssuer("://identity")}, wantErr: true},
+		"incomplete service key": {opts: []Option{WithServiceAccountKey("key", "", "service-account@example.com")}, wantErr: true},
+		"invalid private key":    {opts: []Option{WithPrivateKey("key", []byte("not a key"), "service-account@example.com")}, wantErr: true},
+		"nil HTTP client":        {opts: []Option{WithHTTPClient(nil)}, wantErr: true},
+		"invalid before valid":   {opts: []Option{WithURL(""), WithURL("http://localhost")}, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := New(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got: %v", tt.wantErr, err)
+			}
+			if tt.check != nil {
+				tt.check(t, client)
+			}
+		})
+	}
+}
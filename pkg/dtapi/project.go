@@ -1,20 +1,22 @@
 // Copyright (c) HashiCorp, Inc.
 
-package dt
+package dtapi
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 	"sync"
 )
 
+// ListProjectResponse is a page of projects.
 type ListProjectResponse struct {
-	Projects []Project `json:"projects"`
+	Projects      []Project `json:"projects"`
+	NextPageToken string    `json:"nextPageToken"`
 }
 
+// Project is a DT project. Name is the resource name, projects/{id}.
 type Project struct {
 	Name                    string   `json:"name"`
 	DisplayName             string   `json:"displayName"`
@@ -26,6 +28,7 @@ type Project struct {
 	Location                Location `json:"location"`
 }
 
+// EditableProject is the part of a project that can be updated.
 type EditableProject struct {
 	Name         string   `json:"name"`
 	DisplayName  string   `json:"displayName"`
@@ -33,10 +36,13 @@ type EditableProject struct {
 	Location     Location `json:"location"`
 }
 
+// ID returns the ID of the project.
 func (p Project) ID() (string, error) {
-	return idFromProject(p.Name)
+	name, err := ParseProjectName(p.Name)
+	return name.ProjectID, err
 }
 
+// Location is the location of a project.
 type Location struct {
 	Latitude     *float64 `json:"latitude"`
 	Longitude    *float64 `json:"longitude"`
@@ -64,6 +70,7 @@ func (c *projectCache) setProject(project Project) {
 	c.projects[project.Name] = project
 }
 
+// GetProject retrieves a project by name. Projects are cached by the client.
 func (c *Client) GetProject(ctx context.Context, projectName string) (Project, error) {
 	// first check if the project is in the cache
 	if project, ok := c.projectCache.getProject(projectName); ok {
@@ -91,7 +98,7 @@ func (c *Client) GetProject(ctx context.Context, projectName string) (Project, e
 
 func (c *Client) listProjects(ctx context.Context) (ListProjectResponse, error) {
 	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects
-	url := fmt.Sprintf("%s/v2/projects", strings.TrimSuffix(c.URL, "/"))
+	url := c.apiURL("v2", "projects")
 
 	// Send a GET request to the API
 	responseBody, err := c.DoRequest(ctx, http.MethodGet, url, nil, nil)
@@ -108,15 +115,46 @@ func (c *Client) listProjects(ctx context.Context) (ListProjectResponse, error)
 	return projects, nil
 }
 
+// ListProjects lists the projects in the organization, following page tokens.
+func (c *Client) ListProjects(ctx context.Context, organization string) ([]Project, error) {
+	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects?organization={organization}
+	url := c.apiURL("v2", "projects")
+	params := map[string]string{
+		"organization": organization,
+		"pageSize":     "100",
+		"pageToken":    "",
+	}
+
+	var projects []Project
+	for {
+		responseBody, err := c.DoRequest(ctx, http.MethodGet, url, nil, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var response ListProjectResponse
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return nil, err
+		}
+		projects = append(projects, response.Projects...)
+
+		if response.NextPageToken == "" {
+			return projects, nil
+		}
+		params["pageToken"] = response.NextPageToken
+	}
+}
+
+// UpdateProject updates the display name and location of a project.
 func (c *Client) UpdateProject(ctx context.Context, project EditableProject) (EditableProject, error) {
 	// Get the project ID from the project name
-	projectID, err := idFromProject(project.Name)
+	projectName, err := ParseProjectName(project.Name)
 	if err != nil {
 		return EditableProject{}, fmt.Errorf("failed to get project ID: %w", err)
 	}
 
 	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects/{project_id}
-	url := fmt.Sprintf("%s/v2/projects/%s", strings.TrimSuffix(c.URL, "/"), projectID)
+	url := c.apiURL("v2", "projects", projectName.ProjectID)
 	body, err := json.Marshal(project)
 	if err != nil {
 		return EditableProject{}, err
@@ -148,9 +186,10 @@ type createProjectRequest struct {
 	} `json:"location"`
 }
 
+// CreateProject creates a project in the organization of the project.
 func (c *Client) CreateProject(ctx context.Context, project Project) (Project, error) {
 	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects
-	url := fmt.Sprintf("%s/v2/projects", strings.TrimSuffix(c.URL, "/"))
+	url := c.apiURL("v2", "projects")
 
 	createProjectRequest := createProjectRequest{
 		DisplayName:  project.DisplayName,
@@ -182,13 +221,13 @@ func (c *Client) CreateProject(ctx context.Context, project Project) (Project, e
 
 func (c *Client) DeleteProject(ctx context.Context, project string) error {
 	// Get the project ID from the project name
-	projectID, err := idFromProject(project)
+	projectName, err := ParseProjectName(project)
 	if err != nil {
 		return fmt.Errorf("failed to get project ID: %w", err)
 	}
 
 	// Create the URL for the API request: https://api.disruptive-technologies.com/v2/projects/{project_id}
-	url := fmt.Sprintf("%s/v2/projects/%s", strings.TrimSuffix(c.URL, "/"), projectID)
+	url := c.apiURL("v2", "projects", projectName.ProjectID)
 
 	// Send a DELETE request to the API
 	_, err = c.DoRequest(ctx, http.MethodDelete, url, nil, nil)
@@ -198,11 +237,3 @@ func (c *Client) DeleteProject(ctx context.Context, project string) error {
 
 	return nil
 }
-
-func idFromProject(project string) (string, error) {
-	parts := strings.Split(project, "/")
-	if len(parts) != 2 {
-		return project, fmt.Errorf("invalid project name: %s", project)
-	}
-	return parts[1], nil
-}
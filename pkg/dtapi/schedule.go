@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// The time zone database is embedded so that time zones can be validated on
+	// hosts without one installed.
+	_ "time/tzdata"
+)
+
+// weekdays are the days of the week in schedule order, starting on Monday.
+var weekdays = []time.Weekday{
+	time.Monday,
+	time.Tuesday,
+	time.Wednesday,
+	time.Thursday,
+	time.Friday,
+	time.Saturday,
+	time.Sunday,
+}
+
+// ParseSchedule parses a compact schedule such as "Mon-Fri 08:00-17:00; Sat 10:00-14:00"
+// into a Schedule in the given time zone.
+//
+// Slots are separated by semicolons. Each slot is a comma separated list of days
+// or day ranges, such as "Mon-Wed,Fri", followed by a comma separated list of
+// time ranges, such as "08:00-12:00,13:00-17:00". Days are either full names or
+// their first three letters, in any case, and "daily" means every day.
+func ParseSchedule(spec, timezone string) (*Schedule, error) {
+	if err := ValidateTimezone(timezone); err != nil {
+		return nil, err
+	}
+
+	schedule := &Schedule{Timezone: timezone, Slots: []Slot{}}
+	for _, slotSpec := range strings.Split(spec, ";") {
+		slotSpec = strings.TrimSpace(slotSpec)
+		if slotSpec == "" {
+			continue
+		}
+		slot, err := parseSlot(slotSpec)
+		if err != nil {
+			return nil, fmt.Errorf("dt: invalid schedule slot %q: %w", slotSpec, err)
+		}
+		schedule.Slots = append(schedule.Slots, slot)
+	}
+	if len(schedule.Slots) == 0 {
+		return nil, fmt.Errorf("dt: invalid schedule %q: no slots", spec)
+	}
+	return schedule, nil
+}
+
+// ValidateTimezone returns an error if timezone is not a name from the IANA time zone database.
+func ValidateTimezone(timezone string) error {
+	if timezone == "" || timezone == "Local" {
+		return fmt.Errorf("dt: invalid time zone %q", timezone)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("dt: invalid time zone %q", timezone)
+	}
+	return nil
+}
+
+func parseSlot(spec string) (Slot, error) {
+	fields := strings.Fields(spec)
+	if len(fields) < 2 {
+		return Slot{}, fmt.Errorf("expected days followed by time ranges")
+	}
+
+	days, err := parseDays(fields[0])
+	if err != nil {
+		return Slot{}, err
+	}
+
+	var timeRanges []TimeRange
+	for _, rangeSpec := range strings.Split(strings.Join(fields[1:], ""), ",") {
+		timeRange, err := parseTimeRange(rangeSpec)
+		if err != nil {
+			return Slot{}, err
+		}
+		for _, other := range timeRanges {
+			if minutes(timeRange.Start) < minutes(other.End) && minutes(other.Start) < minutes(timeRange.End) {
+				return Slot{}, fmt.Errorf("time range %q overlaps another time range", rangeSpec)
+			}
+		}
+		timeRanges = append(timeRanges, timeRange)
+	}
+
+	return Slot{DaysOfWeek: days, TimeRange: timeRanges}, nil
+}
+
+// parseDays returns the days of the spec in schedule order. Daily returns an
+// empty list, which the API treats as every day.
+func parseDays(spec string) ([]string, error) {
+	if strings.EqualFold(spec, "daily") {
+		return []string{}, nil
+	}
+
+	selected := make(map[int]bool)
+	for _, daySpec := range strings.Split(spec, ",") {
+		first, last, isRange := strings.Cut(daySpec, "-")
+		start, err := parseDay(first)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseDay(last); err != nil {
+				return nil, err
+			}
+		}
+		// ranges may wrap around the end of the week, e.g. Fri-Mon
+		for i := start; ; i = (i + 1) % len(weekdays) {
+			selected[i] = true
+			if i == end {
+				break
+			}
+		}
+	}
+
+	days := make([]string, 0, len(selected))
+	for i, weekday := range weekdays {
+		if selected[i] {
+			days = append(days, weekday.String())
+		}
+	}
+	return days, nil
+}
+
+// parseDay returns the index in weekdays of the full or abbreviated day name.
+func parseDay(name string) (int, error) {
+	for i, weekday := range weekdays {
+		if strings.EqualFold(name, weekday.String()) || strings.EqualFold(name, weekday.String()[:3]) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", name)
+}
+
+func parseTimeRange(spec string) (TimeRange, error) {
+	startSpec, endSpec, ok := strings.Cut(spec, "-")
+	if !ok {
+		return TimeRange{}, fmt.Errorf("invalid time range %q: expected HH:MM-HH:MM", spec)
+	}
+	start, err := parseTimeOfDay(startSpec)
+	if err != nil {
+		return TimeRange{}, err
+	}
+	end, err := parseTimeOfDay(endSpec)
+	if err != nil {
+		return TimeRange{}, err
+	}
+	if minutes(start) >= minutes(end) {
+		return TimeRange{}, fmt.Errorf("invalid time range %q: start must be before end", spec)
+	}
+	return TimeRange{Start: start, End: end}, nil
+}
+
+func parseTimeOfDay(spec string) (TimeOfDay, error) {
+	hourSpec, minuteSpec, ok := strings.Cut(spec, ":")
+	if !ok || len(hourSpec) == 0 || len(hourSpec) > 2 || len(minuteSpec) != 2 || !isDigits(hourSpec+minuteSpec) {
+		return TimeOfDay{}, fmt.Errorf("invalid time %q: expected HH:MM", spec)
+	}
+	hour, err := strconv.ParseInt(hourSpec, 10, 32)
+	if err != nil || hour < 0 || hour > 23 {
+		return TimeOfDay{}, fmt.Errorf("invalid time %q: hour must be between 00 and 23", spec)
+	}
+	minute, err := strconv.ParseInt(minuteSpec, 10, 32)
+	if err != nil || minute < 0 || minute > 59 {
+		return TimeOfDay{}, fmt.Errorf("invalid time %q: minute must be between 00 and 59", spec)
+	}
+	return TimeOfDay{Hour: int32(hour), Minute: int32(minute)}, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func minutes(t TimeOfDay) int32 {
+	return t.Hour*60 + t.Minute
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSchedule(t *testing.T) {
+	t.Parallel()
+
+	weekdays := []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}
+	officeHours := []TimeRange{{Start: TimeOfDay{Hour: 8}, End: TimeOfDay{Hour: 17}}}
+
+	tests := map[string]struct {
+		spec     string
+		timezone string
+		want     []Slot
+		wantErr  bool
+	}{
+		"weekdays and saturday": {
+			spec:     "Mon-Fri 08:00-17:00; Sat 10:00-14:00",
+			timezone: "Europe/Oslo",
+			want: []Slot{
+				{DaysOfWeek: weekdays, TimeRange: officeHours},
+				{DaysOfWeek: []string{"Saturday"}, TimeRange: []TimeRange{{Start: TimeOfDay{Hour: 10}, End: TimeOfDay{Hour: 14}}}},
+			},
+		},
+		"full day names and lists": {
+			spec:     "friday,monday-wednesday,THU 8:00-17:00",
+			timezone: "UTC",
+			want:     []Slot{{DaysOfWeek: weekdays, TimeRange: officeHours}},
+		},
+		"range wrapping the week": {
+			spec:     "Sat-Mon 00:00-23:59",
+			timezone: "UTC",
+			want:     []Slot{{DaysOfWeek: []string{"Monday", "Saturday", "Sunday"}, TimeRange: []TimeRange{{End: TimeOfDay{Hour: 23, Minute: 59}}}}},
+		},
+		"daily with several time ranges": {
+			spec:     "daily 08:00-11:30, 12:15-17:00;",
+			timezone: "America/Los_Angeles",
+			want: []Slot{{DaysOfWeek: []string{}, TimeRange: []TimeRange{
+				{Start: TimeOfDay{Hour: 8}, End: TimeOfDay{Hour: 11, Minute: 30}},
+				{Start: TimeOfDay{Hour: 12, Minute: 15}, End: TimeOfDay{Hour: 17}},
+			}}},
+		},
+		"unknown day":         {spec: "Mon-Fry 08:00-17:00", timezone: "UTC", wantErr: true},
+		"end before start":    {spec: "Mon 17:00-08:00", timezone: "UTC", wantErr: true},
+		"empty time range":    {spec: "Mon 08:00-08:00", timezone: "UTC", wantErr: true},
+		"overlapping ranges":  {spec: "Mon 08:00-12:00,11:00-13:00", timezone: "UTC", wantErr: true},
+		"invalid hour":        {spec: "Mon 08:00-24:00", timezone: "UTC", wantErr: true},
+		"invalid minute":      {spec: "Mon 08:00-17:60", timezone: "UTC", wantErr: true},
+		"signed time":         {spec: "Mon +8:00-17:00", timezone: "UTC", wantErr: true},
+		"missing time ranges": {spec: "Mon-Fri", timezone: "UTC", wantErr: true},
+		"no slots":            {spec: " ; ", timezone: "UTC", wantErr: true},
+		"unknown time zone":   {spec: "Mon 08:00-17:00", timezone: "Europe/Bergen", wantErr: true},
+		"empty time zone":     {spec: "Mon 08:00-17:00", timezone: "", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSchedule(tt.spec, tt.timezone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := &Schedule{Timezone: tt.timezone, Slots: tt.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Temperature units accepted by ConvertTemperature, and the types of a Range.
+const (
+	TemperatureCelsius    = "C"
+	TemperatureFahrenheit = "F"
+	TemperatureKelvin     = "K"
+
+	RangeTypeWithin  = "WITHIN"
+	RangeTypeOutside = "OUTSIDE"
+
+	// temperaturePrecision is the number of decimals kept for converted temperatures,
+	// so that converted thresholds read back from the API compare equal to the plan.
+	temperaturePrecision = 2
+)
+
+// TemperatureUnits returns the accepted temperature units.
+func TemperatureUnits() []string {
+	return []string{TemperatureCelsius, TemperatureFahrenheit, TemperatureKelvin}
+}
+
+// ParseTemperatureUnit returns the temperature unit of a unit symbol or name, such
+// as "F", "°F" or "fahrenheit", in any case.
+func ParseTemperatureUnit(unit string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(strings.TrimSpace(unit), "°")) {
+	case "c", "celsius":
+		return TemperatureCelsius, nil
+	case "f", "fahrenheit":
+		return TemperatureFahrenheit, nil
+	case "k", "kelvin":
+		return TemperatureKelvin, nil
+	}
+	return "", fmt.Errorf("dt: unknown temperature unit %q, must be one of %s", unit, strings.Join(TemperatureUnits(), ", "))
+}
+
+// ConvertTemperature converts the temperature between units, rounded to two decimals.
+func ConvertTemperature(value float64, from, to string) (float64, error) {
+	from, err := ParseTemperatureUnit(from)
+	if err != nil {
+		return 0, err
+	}
+	to, err = ParseTemperatureUnit(to)
+	if err != nil {
+		return 0, err
+	}
+
+	celsius := value
+	switch from {
+	case TemperatureFahrenheit:
+		celsius = (value - 32) * 5 / 9
+	case TemperatureKelvin:
+		celsius = value - 273.15
+	}
+	if celsius < -273.15 {
+		return 0, fmt.Errorf("dt: temperature %g%s is below absolute zero", value, from)
+	}
+
+	converted := celsius
+	switch to {
+	case TemperatureFahrenheit:
+		converted = celsius*9/5 + 32
+	case TemperatureKelvin:
+		converted = celsius + 273.15
+	}
+	return RoundTemperature(converted), nil
+}
+
+// RoundTemperature rounds the temperature to two decimals.
+func RoundTemperature(value float64) float64 {
+	scale := math.Pow10(temperaturePrecision)
+	return math.Round(value*scale) / scale
+}
+
+// ParseTemperatureRange parses a temperature range of a notification rule trigger
+// from a spec such as "between 35F and 41F". The bounds are converted to Celsius.
+//
+// The accepted forms are "between A and B" and "outside A and B", which give a
+// WITHIN or OUTSIDE range, and "above A" and "below B", which give a WITHIN range
+// with only the lower or upper bound. Temperatures without a unit are Celsius.
+func ParseTemperatureRange(spec string) (*Range, error) {
+	fields := strings.Fields(strings.ToLower(spec))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("dt: invalid temperature range %q: empty", spec)
+	}
+
+	var lower, upper string
+	rangeType := RangeTypeWithin
+	switch {
+	case fields[0] == "above" && len(fields) == 2:
+		lower = fields[1]
+	case fields[0] == "below" && len(fields) == 2:
+		upper = fields[1]
+	case (fields[0] == "between" || fields[0] == "outside") && len(fields) == 4 && (fields[2] == "and" || fields[2] == "to"):
+		lower, upper = fields[1], fields[3]
+		if fields[0] == "outside" {
+			rangeType = RangeTypeOutside
+		}
+	default:
+		return nil, fmt.Errorf(`dt: invalid temperature range %q: expected "between A and B", "outside A and B", "above A" or "below B"`, spec)
+	}
+
+	temperatureRange := &Range{Type: rangeType}
+	for _, bound := range []struct {
+		spec  string
+		value **float64
+	}{{lower, &temperatureRange.Lower}, {upper, &temperatureRange.Upper}} {
+		if bound.spec == "" {
+			continue
+		}
+		celsius, err := parseTemperature(bound.spec)
+		if err != nil {
+			return nil, fmt.Errorf("dt: invalid temperature range %q: %w", spec, err)
+		}
+		*bound.value = &celsius
+	}
+	if temperatureRange.Lower != nil && temperatureRange.Upper != nil && *temperatureRange.Lower >= *temperatureRange.Upper {
+		return nil, fmt.Errorf("dt: invalid temperature range %q: lower bound must be below the upper bound", spec)
+	}
+	return temperatureRange, nil
+}
+
+// parseTemperature parses a temperature with an optional unit suffix, such as
+// "41F" or "-3.5°C", and returns it in Celsius.
+func parseTemperature(spec string) (float64, error) {
+	number := strings.TrimRightFunc(spec, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	unit := TemperatureCelsius
+	if suffix := spec[len(number):]; suffix != "" {
+		var err error
+		if unit, err = ParseTemperatureUnit(suffix); err != nil {
+			return 0, err
+		}
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid temperature %q", spec)
+	}
+	return ConvertTemperature(value, unit, TemperatureCelsius)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dtapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertTemperature(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value    float64
+		from, to string
+		want     float64
+		wantErr  bool
+	}{
+		"fahrenheit to celsius":  {value: 35, from: "F", to: "C", want: 1.67},
+		"celsius to fahrenheit":  {value: 1.67, from: "celsius", to: "°F", want: 35.01},
+		"kelvin to celsius":      {value: 0, from: "K", to: "C", want: -273.15},
+		"celsius to kelvin":      {value: 21.5, from: "c", to: "kelvin", want: 294.65},
+		"fahrenheit to kelvin":   {value: 32, from: "F", to: "K", want: 273.15},
+		"same unit":              {value: 4.123, from: "C", to: "C", want: 4.12},
+		"unknown unit":           {value: 1, from: "R", to: "C", wantErr: true},
+		"below absolute zero":    {value: -1, from: "K", to: "C", wantErr: true},
+		"below absolute zero, F": {value: -500, from: "F", to: "K", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ConvertTemperature(tt.value, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseTemperatureRange(t *testing.T) {
+	t.Parallel()
+
+	celsius := func(value float64) *float64 { return &value }
+
+	tests := map[string]struct {
+		spec    string
+		want    *Range
+		wantErr bool
+	}{
+		"between fahrenheit": {
+			spec: "between 35F and 41F",
+			want: &Range{Lower: celsius(1.67), Upper: celsius(5), Type: RangeTypeWithin},
+		},
+		"outside celsius": {
+			spec: "Outside -3.5°C to 8",
+			want: &Range{Lower: celsius(-3.5), Upper: celsius(8), Type: RangeTypeOutside},
+		},
+		"above":               {spec: "above 8C", want: &Range{Lower: celsius(8), Type: RangeTypeWithin}},
+		"below kelvin":        {spec: "below 273.15K", want: &Range{Upper: celsius(0), Type: RangeTypeWithin}},
+		"lower above upper":   {spec: "between 41F and 35F", wantErr: true},
+		"equal bounds":        {spec: "between 5C and 41F", wantErr: true},
+		"unknown unit":        {spec: "above 5R", wantErr: true},
+		"missing temperature": {spec: "above", wantErr: true},
+		"missing upper bound": {spec: "between 35F and", wantErr: true},
+		"invalid number":      {spec: "below 1.2.3C", wantErr: true},
+		"unknown form":        {spec: "around 5C", wantErr: true},
+		"empty":               {spec: "", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTemperatureRange(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -5,7 +5,7 @@ data "dt_project" "test" {
 }
 
 resource "dt_emulator" "test" {
-  display_name = "Added emulator"
+  display_name = "Acceptance Test added emulator"
   project_id   = data.dt_project.test.id
   type         = "co2"
 }
@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_emulator" "test" {
-  display_name = "Emulator with custom labels"
+  display_name = "Acceptance Test emulator with custom labels"
   project_id   = "d0ito5m62hus73ae3lr0"
   type         = "temperature"
   labels = {
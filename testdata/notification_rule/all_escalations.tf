@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "test" {
-  display_name = "All escalation types"
+  display_name = "Acceptance Test all escalation types"
   project_id   = data.dt_project.test.id
 
   trigger = {
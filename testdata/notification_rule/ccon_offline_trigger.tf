@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "test" {
-  display_name = "Cloud connector offline"
+  display_name = "Acceptance Test cloud connector offline"
   project_id   = data.dt_project.test.id
 
   trigger = {
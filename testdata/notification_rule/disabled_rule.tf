@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "my_notification_rule" {
-  display_name = "Disabled notification rule"
+  display_name = "Acceptance Test disabled notification rule"
   enabled      = false
   project_id   = data.dt_project.test.id
   trigger = {
@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "test" {
-  display_name = "Notification Rule Acceptance Test Updated"
+  display_name = "Acceptance Test notification rule updated"
   project_id   = data.dt_project.test.id
   trigger = {
     field = "temperature"
@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "test" {
-  display_name = "Off Hours Schedule"
+  display_name = "Acceptance Test off hours schedule"
   project_id   = data.dt_project.test.id
   schedule = {
     timezone = "Europe/Oslo"
@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "my_notification_rule" {
-  display_name = "Range with PET filter"
+  display_name = "Acceptance Test range with PET filter"
   project_id   = data.dt_project.test.id
   trigger = {
     field = "temperature"
@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "test" {
-  display_name = "With reminder notification"
+  display_name = "Acceptance Test reminder notification"
   project_id   = data.dt_project.test.id
   trigger = {
     field = "relativeHumidity"
@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "test" {
-  display_name = "Signal Tower"
+  display_name = "Acceptance Test signal tower"
   project_id   = data.dt_project.test.id
 
   trigger = {
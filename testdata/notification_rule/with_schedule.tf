@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_notification_rule" "test" {
-  display_name = "Notification Rule Acceptance Test"
+  display_name = "Acceptance Test notification rule"
   project_id   = data.dt_project.test.id
   device_labels = {
     foo = "bar"
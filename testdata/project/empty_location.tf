@@ -1,7 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
 resource "dt_project" "test" {
-  display_name = "Empty Location Project"
+  display_name = "Acceptance Test empty location project"
   organization = "organizations/cvinmt9aq9sc738g6eog"
   location     = {}
 }